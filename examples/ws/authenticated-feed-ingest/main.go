@@ -34,6 +34,10 @@ func main() {
 			}
 
 			switch v := msg.(type) {
+			case mux.AuthLost:
+				log.Printf("auth lost, reconnecting: %s\n", v.Err)
+			case mux.AuthRestored:
+				log.Printf("auth restored after %d attempts\n", v.Attempts)
 			case event.Info:
 				log.Printf("%T: %+v\n", v, v)
 			case order.New:
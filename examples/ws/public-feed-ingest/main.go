@@ -103,6 +103,8 @@ func main() {
 			}
 
 			switch v := msg.(type) {
+			case mux.StaleChannel:
+				log.Printf("stale channel %d, resubscribing: %+v\n", v.ChanID, v.Subscribe)
 			case event.Info:
 				log.Printf("%T: %+v\n", v, v)
 			case trades.TradeSnapshot:
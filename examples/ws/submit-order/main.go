@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
@@ -47,15 +49,20 @@ func main() {
 			os.Exit(1)
 		case <-auth:
 			// authenticated, safe to submit orders etc
-			if err := m.Send(&order.NewRequest{
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			n, err := m.SubmitOrder(ctx, &order.NewRequest{
 				CID:    788,
 				Type:   "EXCHANGE LIMIT",
 				Symbol: "tBTCUSD",
 				Price:  33,
 				Amount: 0.001,
-			}); err != nil {
+			})
+			cancel()
+			if err != nil {
 				fmt.Printf("err submitting new order: %s\n", err)
+				continue
 			}
+			log.Printf("order acknowledged: %s\n", n.Text)
 		}
 	}
 }
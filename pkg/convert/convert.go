@@ -57,10 +57,12 @@ func ToInt(in interface{}) int {
 	return out
 }
 
+// ToInterfaceArray converts slice of slices. Items which are not slices
+// are left nil, so that parsers reject them as too short
 func ToInterfaceArray(i []interface{}) [][]interface{} {
 	newArr := make([][]interface{}, len(i))
 	for index, item := range i {
-		newArr[index] = item.([]interface{})
+		newArr[index], _ = item.([]interface{})
 	}
 	return newArr
 }
@@ -85,11 +87,13 @@ func ToFloat64Array(i [][]interface{}) ([][]float64, error) {
 	return newArr, nil
 }
 
+// FloatToJsonNumber converts number to json.Number. Values of other types
+// are converted as zero
 func FloatToJsonNumber(i interface{}) json.Number {
 	if r, ok := i.(json.Number); ok {
 		return r
 	}
-	return json.Number(strconv.FormatFloat(i.(float64), 'f', -1, 64))
+	return json.Number(strconv.FormatFloat(F64ValOrZero(i), 'f', -1, 64))
 }
 
 func I64ValOrZero(in interface{}) (out int64) {
@@ -1,6 +1,7 @@
 package convert_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
@@ -69,6 +70,30 @@ func TestToInterface(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
+func TestToInterfaceArray(t *testing.T) {
+	payload := []interface{}{[]interface{}{1.0, 2.0}, "hb", nil}
+	expected := [][]interface{}{{1.0, 2.0}, nil, nil}
+	got := convert.ToInterfaceArray(payload)
+	assert.Equal(t, expected, got)
+}
+
+func TestFloatToJsonNumber(t *testing.T) {
+	t.Run("keeps json number", func(t *testing.T) {
+		got := convert.FloatToJsonNumber(json.Number("0.00012300"))
+		assert.Equal(t, json.Number("0.00012300"), got)
+	})
+
+	t.Run("converts float64", func(t *testing.T) {
+		got := convert.FloatToJsonNumber(7254.7)
+		assert.Equal(t, json.Number("7254.7"), got)
+	})
+
+	t.Run("converts other types to zero", func(t *testing.T) {
+		got := convert.FloatToJsonNumber("abc")
+		assert.Equal(t, json.Number("0"), got)
+	})
+}
+
 func TestF64ValOrZero(t *testing.T) {
 	t.Run("converts int to float64", func(t *testing.T) {
 		var expected float64 = 910
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package convert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+func FuzzSplitArray(f *testing.F) {
+	for _, seed := range []string{`[]`, `[1,"te",[7254.7,3,-0.3]]`, `[{"a":"]"},null,true,false]`, `[1,`, `null`} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		parts, err := convert.SplitArray(nil, []byte(data))
+
+		var raw []interface{}
+		jsonErr := json.Unmarshal([]byte(data), &raw)
+		if jsonErr == nil && raw == nil {
+			// json null decodes into nil slice, but it is not an array
+			return
+		}
+		if jsonErr == nil && err != nil {
+			t.Fatalf("valid array %s not split: %s", data, err)
+		}
+		if jsonErr == nil && len(parts) != len(raw) {
+			t.Fatalf("split %s into %d elements, expected %d", data, len(parts), len(raw))
+		}
+	})
+}
+
+func FuzzF64BytesOrZero(f *testing.F) {
+	for _, seed := range []string{`0`, `7254.7`, `-0.00023647`, `1.5948918e+12`, `123456789012345678901234`, `1e-400`} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		var expected float64
+		if err := json.Unmarshal([]byte(data), &expected); err != nil || strings.TrimSpace(data) != data {
+			return
+		}
+
+		got := convert.F64BytesOrZero([]byte(data))
+		if got != expected {
+			t.Fatalf("parsed %s as %v, expected %v", data, got, expected)
+		}
+	})
+}
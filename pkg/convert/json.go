@@ -0,0 +1,332 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// float64 powers of ten which are exact, used by fast number parsing
+var pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11,
+	1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// SplitArray appends raw bytes of every element of json array b to dst and
+// returns extended slice. Elements are not decoded, nested arrays and objects
+// are kept whole and strings keep their quotes. Passing dst with enough
+// capacity splits the array without allocating
+func SplitArray(dst [][]byte, b []byte) ([][]byte, error) {
+	err := eachElement(b, func(elem []byte) {
+		dst = append(dst, elem)
+	})
+	return dst, err
+}
+
+// SplitF64Array appends every element of json array b to dst as float64 and
+// returns extended slice. Elements which are not numbers are converted as
+// zero, same as F64ValOrZero does
+func SplitF64Array(dst []float64, b []byte) ([]float64, error) {
+	err := eachElement(b, func(elem []byte) {
+		dst = append(dst, F64BytesOrZero(elem))
+	})
+	return dst, err
+}
+
+// F64ValsOrZero appends every value of raw to dst as float64 and returns
+// extended slice. Values which are not numbers are converted as zero
+func F64ValsOrZero(dst []float64, raw []interface{}) []float64 {
+	for _, v := range raw {
+		dst = append(dst, F64ValOrZero(v))
+	}
+	return dst
+}
+
+// eachElement calls fn with raw bytes of every element of json array b
+func eachElement(b []byte, fn func(elem []byte)) error {
+	i := skipSpace(b, 0)
+	if i == len(b) || b[i] != '[' {
+		return fmt.Errorf("expected json array, got: %s", b)
+	}
+
+	i = skipSpace(b, i+1)
+	if i < len(b) && b[i] == ']' {
+		return trailing(b, i+1)
+	}
+
+	for i < len(b) {
+		end, err := skipValue(b, i)
+		if err != nil {
+			return err
+		}
+		fn(b[i:end])
+
+		i = skipSpace(b, end)
+		if i == len(b) {
+			break
+		}
+
+		switch b[i] {
+		case ',':
+			i = skipSpace(b, i+1)
+		case ']':
+			return trailing(b, i+1)
+		default:
+			return fmt.Errorf("unexpected %q in json array: %s", b[i], b)
+		}
+	}
+
+	return fmt.Errorf("unterminated json array: %s", b)
+}
+
+// IsArray reports whether raw json value is an array
+func IsArray(b []byte) bool {
+	i := skipSpace(b, 0)
+	return i < len(b) && b[i] == '['
+}
+
+// F64BytesOrZero parses raw json number without allocating. Values which are
+// not numbers are converted as zero, same as F64ValOrZero does
+func F64BytesOrZero(b []byte) float64 {
+	f, _ := parseNumber(b)
+	return f
+}
+
+// I64BytesOrZero parses raw json number without allocating. Fractions are
+// truncated and values which are not numbers are converted as zero, same as
+// I64ValOrZero does
+func I64BytesOrZero(b []byte) int64 {
+	neg := len(b) > 0 && b[0] == '-'
+	digits := b
+	if neg {
+		digits = b[1:]
+	}
+
+	// plain integers are parsed exactly, without going through float64
+	if len(digits) > 0 && len(digits) < 19 {
+		var n int64
+		for _, c := range digits {
+			if c < '0' || c > '9' {
+				return int64(F64BytesOrZero(b))
+			}
+			n = n*10 + int64(c-'0')
+		}
+		if neg {
+			return -n
+		}
+		return n
+	}
+
+	return int64(F64BytesOrZero(b))
+}
+
+// BytesToJsonNumber returns raw json number as json.Number, keeping its
+// original representation. Values which are not numbers are converted as zero
+func BytesToJsonNumber(b []byte) json.Number {
+	if _, ok := parseNumber(b); !ok {
+		return json.Number("0")
+	}
+	return json.Number(b)
+}
+
+// parseNumber parses json number. Numbers with up to 15 significant digits and
+// small exponents, which covers prices and amounts, are parsed without
+// allocating, others fall back to strconv
+func parseNumber(b []byte) (float64, bool) {
+	if !IsNumber(b) {
+		return 0, false
+	}
+
+	i := 0
+	neg := b[0] == '-'
+	if neg {
+		i++
+	}
+
+	var mant uint64
+	digits, exp := 0, 0
+	for ; i < len(b) && isDigit(b[i]); i++ {
+		if digits >= 19 {
+			return parseFloat(b)
+		}
+		mant = mant*10 + uint64(b[i]-'0')
+		if mant > 0 {
+			digits++
+		}
+	}
+
+	if i < len(b) && b[i] == '.' {
+		for i++; i < len(b) && isDigit(b[i]); i++ {
+			if digits >= 19 {
+				return parseFloat(b)
+			}
+			mant = mant*10 + uint64(b[i]-'0')
+			if mant > 0 {
+				digits++
+			}
+			exp--
+		}
+	}
+
+	if i < len(b) {
+		// exponent, isNumber guarantees its syntax
+		i++
+		eneg := b[i] == '-'
+		if b[i] == '-' || b[i] == '+' {
+			i++
+		}
+		e := 0
+		for ; i < len(b); i++ {
+			if e > 1000 {
+				return parseFloat(b)
+			}
+			e = e*10 + int(b[i]-'0')
+		}
+		if eneg {
+			e = -e
+		}
+		exp += e
+	}
+
+	if mant > 1<<53 || exp < -22 || exp > 22 {
+		return parseFloat(b)
+	}
+
+	f := float64(mant)
+	if exp < 0 {
+		f /= pow10[-exp]
+	} else {
+		f *= pow10[exp]
+	}
+	if neg {
+		f = -f
+	}
+	return f, true
+}
+
+func parseFloat(b []byte) (float64, bool) {
+	f, err := strconv.ParseFloat(string(b), 64)
+	return f, err == nil
+}
+
+// IsNumber validates json number syntax
+func IsNumber(b []byte) bool {
+	i := 0
+	if i < len(b) && b[i] == '-' {
+		i++
+	}
+
+	switch {
+	case i < len(b) && b[i] == '0':
+		i++
+	case i < len(b) && isDigit(b[i]):
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+	default:
+		return false
+	}
+
+	if i < len(b) && b[i] == '.' {
+		i++
+		if i == len(b) || !isDigit(b[i]) {
+			return false
+		}
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+	}
+
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '-' || b[i] == '+') {
+			i++
+		}
+		if i == len(b) || !isDigit(b[i]) {
+			return false
+		}
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+	}
+
+	return i == len(b)
+}
+
+// skipValue returns index right after json value starting at i. Nested values
+// are only checked to be balanced, literals are validated
+func skipValue(b []byte, i int) (int, error) {
+	switch b[i] {
+	case '"':
+		return skipString(b, i)
+	case '[', '{':
+		depth := 0
+		for j := i; j < len(b); j++ {
+			switch b[j] {
+			case '"':
+				end, err := skipString(b, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("unterminated json value: %s", b[i:])
+	}
+
+	end := i
+	for end < len(b) && b[end] != ',' && b[end] != ']' && !isSpace(b[end]) {
+		end++
+	}
+
+	lit := b[i:end]
+	switch string(lit) {
+	case "null", "true", "false":
+		return end, nil
+	}
+	if !IsNumber(lit) {
+		return 0, fmt.Errorf("invalid json literal: %s", lit)
+	}
+	return end, nil
+}
+
+func skipString(b []byte, i int) (int, error) {
+	for j := i + 1; j < len(b); j++ {
+		switch b[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated json string: %s", b[i:])
+}
+
+func trailing(b []byte, i int) error {
+	if i = skipSpace(b, i); i != len(b) {
+		return fmt.Errorf("unexpected data after json array: %s", b[i:])
+	}
+	return nil
+}
+
+func skipSpace(b []byte, i int) int {
+	for i < len(b) && isSpace(b[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
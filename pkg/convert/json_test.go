@@ -0,0 +1,136 @@
+package convert_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArray(t *testing.T) {
+	cases := map[string]struct {
+		pld      string
+		expected []string
+		err      bool
+	}{
+		"empty": {
+			pld:      ` [ ] `,
+			expected: nil,
+		},
+		"numbers": {
+			pld:      `[7254.7, 3,-0.3e-2]`,
+			expected: []string{"7254.7", "3", "-0.3e-2"},
+		},
+		"nested values are kept whole": {
+			pld:      `[1,"te",[[1,"a]"],{"b":[2]}],null,true]`,
+			expected: []string{"1", `"te"`, `[[1,"a]"],{"b":[2]}]`, "null", "true"},
+		},
+		"escaped strings": {
+			pld:      `["a\"],b"]`,
+			expected: []string{`"a\"],b"`},
+		},
+		"not an array":        {pld: `{"event":"info"}`, err: true},
+		"unterminated":        {pld: `[1,2`, err: true},
+		"unterminated nested": {pld: `[1,[2]`, err: true},
+		"unterminated string": {pld: `["abc]`, err: true},
+		"missing element":     {pld: `[1,,2]`, err: true},
+		"trailing comma":      {pld: `[1,2,]`, err: true},
+		"invalid literal":     {pld: `[1,tru]`, err: true},
+		"invalid number":      {pld: `[01]`, err: true},
+		"data after array":    {pld: `[1] 2`, err: true},
+		"missing separator":   {pld: `[1 2]`, err: true},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := convert.SplitArray(nil, []byte(v.pld))
+			if v.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			var gotStr []string
+			for _, e := range got {
+				gotStr = append(gotStr, string(e))
+			}
+			assert.Equal(t, v.expected, gotStr)
+		})
+	}
+}
+
+func TestSplitF64Array(t *testing.T) {
+	got, err := convert.SplitF64Array(nil, []byte(`[1,"2",null,-0.5,[1]]`))
+	require.Nil(t, err)
+	assert.Equal(t, []float64{1, 0, 0, -0.5, 0}, got)
+}
+
+func TestF64ValsOrZero(t *testing.T) {
+	got := convert.F64ValsOrZero(nil, []interface{}{1.5, 2, "3", nil})
+	assert.Equal(t, []float64{1.5, 2, 0, 0}, got)
+}
+
+func TestF64BytesOrZero(t *testing.T) {
+	for _, v := range []string{
+		"0", "-0", "1", "7254.7", "0.00023647", "-59.84", "1574694605000",
+		"1.5948918e+12", "3E-5", "0.1", "9007199254740993", "123456789012345678901234",
+		"1e23", "1e-300", "4.9406564584124654e-324", "1.7976931348623157e308",
+	} {
+		expected, err := strconv.ParseFloat(v, 64)
+		require.Nil(t, err)
+		assert.Equal(t, expected, convert.F64BytesOrZero([]byte(v)), v)
+	}
+
+	for _, v := range []string{"", `"1"`, "null", "true", "1.", ".1", "+1", "1e", "0x10", "1 "} {
+		assert.Equal(t, float64(0), convert.F64BytesOrZero([]byte(v)), v)
+	}
+}
+
+func TestI64BytesOrZero(t *testing.T) {
+	cases := map[string]int64{
+		"34006738527":        34006738527,
+		"-1471812406":        -1471812406,
+		"1.5948918e+12":      1594891800000,
+		"2.9":                2,
+		"999999999999999999": 999999999999999999,
+		`"1"`:                0,
+		"null":               0,
+	}
+
+	for k, v := range cases {
+		assert.Equal(t, v, convert.I64BytesOrZero([]byte(k)), k)
+	}
+}
+
+func TestBytesToJsonNumber(t *testing.T) {
+	assert.Equal(t, json.Number("0.00012300"), convert.BytesToJsonNumber([]byte("0.00012300")))
+	assert.Equal(t, json.Number("0"), convert.BytesToJsonNumber([]byte(`"abc"`)))
+}
+
+func TestIsArray(t *testing.T) {
+	assert.True(t, convert.IsArray([]byte(" [1]")))
+	assert.False(t, convert.IsArray([]byte(`"[1]"`)))
+	assert.False(t, convert.IsArray(nil))
+}
+
+func BenchmarkSplitArray(b *testing.B) {
+	pld := []byte(`[34006738527,7254.7,-0.3]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var buf [4][]byte
+		if _, err := convert.SplitArray(buf[:0], pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkF64BytesOrZero(b *testing.B) {
+	pld := []byte(`0.00023647`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		convert.F64BytesOrZero(pld)
+	}
+}
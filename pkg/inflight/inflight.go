@@ -0,0 +1,270 @@
+package inflight
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+)
+
+// Tracker keeps track of authenticated requests which were sent to the api
+// but not yet acknowledged with a notification. Safe for concurrent use
+type Tracker struct {
+	mtx      sync.Mutex
+	pending  map[string]int
+	waiters  []chan struct{}
+	awaiting []*await
+}
+
+// await is a caller waiting for notification accepted by match
+type await struct {
+	match func(*notification.Notification) bool
+	ch    chan *notification.Notification
+}
+
+// New returns pointer to empty Tracker
+func New() *Tracker {
+	return &Tracker{
+		pending: make(map[string]int),
+	}
+}
+
+// Key returns correlation key of the request payload. Empty key is returned
+// for payloads which can't be matched with a notification, i.e. new orders
+// without client id
+func Key(pld interface{}) string {
+	switch v := pld.(type) {
+	case *order.NewRequest:
+		return newKey(v.CID)
+	case order.NewRequest:
+		return newKey(v.CID)
+	case *order.UpdateRequest:
+		return idKey("ou", v.ID)
+	case order.UpdateRequest:
+		return idKey("ou", v.ID)
+	case *order.CancelRequest:
+		return cancelKey(v.ID, v.CID)
+	case order.CancelRequest:
+		return cancelKey(v.ID, v.CID)
+	case *fundingoffer.CancelRequest:
+		return idKey("foc", v.ID)
+	case fundingoffer.CancelRequest:
+		return idKey("foc", v.ID)
+	case *fundingloan.CancelRequest:
+		return idKey("flc", v.ID)
+	case fundingloan.CancelRequest:
+		return idKey("flc", v.ID)
+	case *fundingcredit.CancelRequest:
+		return idKey("fcc", v.ID)
+	case fundingcredit.CancelRequest:
+		return idKey("fcc", v.ID)
+	}
+	return ""
+}
+
+// Add marks request payload as in flight and returns its key. Payloads
+// which can't be correlated are not tracked and empty key is returned
+func (t *Tracker) Add(pld interface{}) string {
+	key := Key(pld)
+	if key == "" {
+		return key
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.pending[key]++
+	return key
+}
+
+// Remove drops single request with given key, i.e. when it failed to send
+func (t *Tracker) Remove(key string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.done(key)
+}
+
+// Ack marks requests acknowledged by the notification as done. Both success
+// and error notifications acknowledge the request
+func (t *Tracker) Ack(n *notification.Notification) {
+	if n == nil {
+		return
+	}
+
+	keys := AckKeys(n)
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, k := range keys {
+		t.done(k)
+	}
+
+	// notification is delivered to the longest waiting caller only
+	for i, a := range t.awaiting {
+		if a.match(n) {
+			a.ch <- n
+			t.awaiting = append(t.awaiting[:i], t.awaiting[i+1:]...)
+			return
+		}
+	}
+}
+
+// Await returns channel receiving the first notification accepted by match
+// and passed to Ack, along with func releasing the wait. It has to be called
+// before the request is sent, so that acknowledgement is not missed
+func (t *Tracker) Await(match func(*notification.Notification) bool) (<-chan *notification.Notification, func()) {
+	a := &await{
+		match: match,
+		ch:    make(chan *notification.Notification, 1),
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.awaiting = append(t.awaiting, a)
+
+	return a.ch, func() {
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		for i, v := range t.awaiting {
+			if v == a {
+				t.awaiting = append(t.awaiting[:i], t.awaiting[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Awaited returns number of callers waiting for notifications
+func (t *Tracker) Awaited() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.awaiting)
+}
+
+// Acks returns match func accepting notifications which acknowledge
+// request with given key, to be used with Await
+func Acks(key string) func(*notification.Notification) bool {
+	return func(n *notification.Notification) bool {
+		for _, k := range AckKeys(n) {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AckKeys returns keys of requests acknowledged by the notification
+func AckKeys(n *notification.Notification) []string {
+	switch n.Type {
+	case "on-req":
+		switch v := n.NotifyInfo.(type) {
+		case order.New:
+			return []string{newKey(v.CID)}
+		case *order.Snapshot:
+			// oco orders are acknowledged as a set
+			keys := make([]string, 0, len(v.Snapshot))
+			for _, o := range v.Snapshot {
+				keys = append(keys, newKey(o.CID))
+			}
+			return keys
+		}
+	case "ou-req":
+		if v, ok := n.NotifyInfo.(order.Update); ok {
+			return []string{idKey("ou", v.ID)}
+		}
+	case "oc-req":
+		if v, ok := n.NotifyInfo.(order.Cancel); ok {
+			return []string{idKey("oc", v.ID), idKey("oc-cid", v.CID)}
+		}
+	case "foc-req":
+		if v, ok := n.NotifyInfo.(fundingoffer.Cancel); ok {
+			return []string{idKey("foc", v.ID)}
+		}
+	case "flc-req", "fcc-req":
+		// loan and credit are passed unparsed, id comes first
+		if v, ok := n.NotifyInfo.([]interface{}); ok && len(v) > 0 {
+			return []string{idKey(n.Type[:3], convert.I64ValOrZero(v[0]))}
+		}
+	}
+	return nil
+}
+
+// Pending returns number of requests in flight
+func (t *Tracker) Pending() (count int) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, v := range t.pending {
+		count += v
+	}
+	return
+}
+
+// Drained returns channel which gets closed once there are no requests in flight
+func (t *Tracker) Drained() <-chan struct{} {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	ch := make(chan struct{})
+	if len(t.pending) == 0 {
+		close(ch)
+		return ch
+	}
+	t.waiters = append(t.waiters, ch)
+	return ch
+}
+
+// Wait blocks until all requests in flight are acknowledged or timeout
+// expires. Returns false if requests were still in flight on timeout
+func (t *Tracker) Wait(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-t.Drained():
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (t *Tracker) done(key string) {
+	if _, ok := t.pending[key]; !ok {
+		return
+	}
+
+	t.pending[key]--
+	if t.pending[key] <= 0 {
+		delete(t.pending, key)
+	}
+
+	if len(t.pending) == 0 {
+		for _, w := range t.waiters {
+			close(w)
+		}
+		t.waiters = nil
+	}
+}
+
+func newKey(cid int64) string {
+	return idKey("on", cid)
+}
+
+func cancelKey(id, cid int64) string {
+	if id != 0 {
+		return idKey("oc", id)
+	}
+	return idKey("oc-cid", cid)
+}
+
+func idKey(prefix string, id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", prefix, id)
+}
@@ -0,0 +1,211 @@
+package inflight_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/inflight"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	cases := map[string]struct {
+		pld      interface{}
+		expected string
+	}{
+		"new order": {
+			pld:      &order.NewRequest{CID: 123},
+			expected: "on:123",
+		},
+		"new order without cid": {
+			pld:      order.NewRequest{},
+			expected: "",
+		},
+		"order update": {
+			pld:      &order.UpdateRequest{ID: 1},
+			expected: "ou:1",
+		},
+		"order cancel by id": {
+			pld:      &order.CancelRequest{ID: 1, CID: 2},
+			expected: "oc:1",
+		},
+		"order cancel by cid": {
+			pld:      order.CancelRequest{CID: 2, CIDDate: "2020-01-01"},
+			expected: "oc-cid:2",
+		},
+		"funding offer cancel": {
+			pld:      &fundingoffer.CancelRequest{ID: 5},
+			expected: "foc:5",
+		},
+		"funding loan close": {
+			pld:      &fundingloan.CancelRequest{ID: 6},
+			expected: "flc:6",
+		},
+		"funding credit close": {
+			pld:      fundingcredit.CancelRequest{ID: 7},
+			expected: "fcc:7",
+		},
+		"funding offer submit": {
+			pld:      &fundingoffer.SubmitRequest{},
+			expected: "",
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, v.expected, inflight.Key(v.pld))
+		})
+	}
+}
+
+func TestAck(t *testing.T) {
+	cases := map[string]struct {
+		pld     interface{}
+		n       *notification.Notification
+		pending int
+	}{
+		"new order acked": {
+			pld: &order.NewRequest{CID: 123},
+			n: &notification.Notification{
+				Type:       "on-req",
+				Status:     "SUCCESS",
+				NotifyInfo: order.New{ID: 1, CID: 123},
+			},
+			pending: 0,
+		},
+		"new order error acked": {
+			pld: &order.NewRequest{CID: 123},
+			n: &notification.Notification{
+				Type:       "on-req",
+				Status:     "ERROR",
+				NotifyInfo: order.New{CID: 123},
+			},
+			pending: 0,
+		},
+		"oco orders acked": {
+			pld: &order.NewRequest{CID: 123, OcoOrder: true},
+			n: &notification.Notification{
+				Type: "on-req",
+				NotifyInfo: &order.Snapshot{
+					Snapshot: []*order.Order{{ID: 1, CID: 123}, {ID: 2, CID: 124}},
+				},
+			},
+			pending: 0,
+		},
+		"cancel by cid acked": {
+			pld: &order.CancelRequest{CID: 123},
+			n: &notification.Notification{
+				Type:       "oc-req",
+				NotifyInfo: order.Cancel{ID: 1, CID: 123},
+			},
+			pending: 0,
+		},
+		"funding offer cancel acked": {
+			pld: &fundingoffer.CancelRequest{ID: 5},
+			n: &notification.Notification{
+				Type:       "foc-req",
+				NotifyInfo: fundingoffer.Cancel{ID: 5},
+			},
+			pending: 0,
+		},
+		"funding credit close acked": {
+			pld: &fundingcredit.CancelRequest{ID: 7},
+			n: &notification.Notification{
+				Type:       "fcc-req",
+				NotifyInfo: []interface{}{float64(7), "fUSD"},
+			},
+			pending: 0,
+		},
+		"unrelated notification": {
+			pld: &order.UpdateRequest{ID: 1},
+			n: &notification.Notification{
+				Type:       "ou-req",
+				NotifyInfo: order.Update{ID: 2},
+			},
+			pending: 1,
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			tr := inflight.New()
+			tr.Add(v.pld)
+			tr.Ack(v.n)
+			assert.Equal(t, v.pending, tr.Pending())
+		})
+	}
+}
+
+func TestWait(t *testing.T) {
+	t.Run("returns right away when nothing is in flight", func(t *testing.T) {
+		tr := inflight.New()
+		assert.True(t, tr.Wait(time.Millisecond))
+	})
+
+	t.Run("times out on unacknowledged request", func(t *testing.T) {
+		tr := inflight.New()
+		tr.Add(&order.NewRequest{CID: 1})
+		assert.False(t, tr.Wait(time.Millisecond*10))
+	})
+
+	t.Run("returns once all requests are acknowledged", func(t *testing.T) {
+		tr := inflight.New()
+		tr.Add(&order.NewRequest{CID: 1})
+		key := tr.Add(&order.UpdateRequest{ID: 2})
+
+		go func() {
+			tr.Ack(&notification.Notification{Type: "on-req", NotifyInfo: order.New{CID: 1}})
+			tr.Remove(key)
+		}()
+
+		assert.True(t, tr.Wait(time.Second))
+		assert.Equal(t, 0, tr.Pending())
+	})
+}
+
+func TestAwait(t *testing.T) {
+	t.Run("delivers acknowledgement to matching caller", func(t *testing.T) {
+		tr := inflight.New()
+		first, release := tr.Await(inflight.Acks("on:1"))
+		defer release()
+		second, release2 := tr.Await(inflight.Acks("on:2"))
+		defer release2()
+		assert.Equal(t, 2, tr.Awaited())
+
+		n := &notification.Notification{Type: "on-req", NotifyInfo: order.New{CID: 2}}
+		tr.Ack(n)
+		assert.Equal(t, n, <-second)
+		assert.Len(t, first, 0)
+		assert.Equal(t, 1, tr.Awaited())
+	})
+
+	t.Run("oldest caller gets notification first", func(t *testing.T) {
+		tr := inflight.New()
+		match := func(n *notification.Notification) bool { return n.Type == "oc_multi-req" }
+		first, release := tr.Await(match)
+		defer release()
+		second, release2 := tr.Await(match)
+		defer release2()
+
+		tr.Ack(&notification.Notification{Type: "oc_multi-req", MessageID: 1})
+		tr.Ack(&notification.Notification{Type: "oc_multi-req", MessageID: 2})
+		assert.Equal(t, int64(1), (<-first).MessageID)
+		assert.Equal(t, int64(2), (<-second).MessageID)
+	})
+
+	t.Run("release stops waiting", func(t *testing.T) {
+		tr := inflight.New()
+		ch, release := tr.Await(inflight.Acks("ou:1"))
+		release()
+		release()
+		assert.Equal(t, 0, tr.Awaited())
+
+		tr.Ack(&notification.Notification{Type: "ou-req", NotifyInfo: order.Update{ID: 1}})
+		assert.Len(t, ch, 0)
+	})
+}
@@ -0,0 +1,31 @@
+// Package fuzzutil holds helpers shared by fuzz targets of model packages
+package fuzzutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// Decode unmarshals fuzzed input, skipping the ones which are not json arrays
+func Decode(t *testing.T, data string) []interface{} {
+	var raw []interface{}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		t.Skip()
+	}
+	return raw
+}
+
+// DecodeNumbers unmarshals fuzzed input twice, as floats and as json numbers,
+// skipping the ones which are not json arrays
+func DecodeNumbers(t *testing.T, data string) ([]interface{}, []interface{}) {
+	raw := Decode(t, data)
+
+	var nums []interface{}
+	d := json.NewDecoder(bytes.NewReader([]byte(data)))
+	d.UseNumber()
+	if err := d.Decode(&nums); err != nil {
+		t.Skip()
+	}
+	return raw, nums
+}
@@ -0,0 +1,55 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// UserInfo describes account the api key belongs to
+type UserInfo struct {
+	ID                     int64
+	Email                  string
+	Username               string
+	MTSAccountCreate       int64
+	Verified               bool
+	VerificationLevel      int64
+	Timezone               string
+	Locale                 string
+	Company                string
+	EmailVerified          bool
+	SubAccountType         string
+	MTSMasterAccountCreate int64
+	GroupID                int64
+}
+
+// SubAccount reports whether account is a sub account of a master account
+func (u *UserInfo) SubAccount() bool {
+	return u.SubAccountType != ""
+}
+
+// UserInfoFromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into an UserInfo.
+func UserInfoFromRaw(raw []interface{}) (*UserInfo, error) {
+	if len(raw) < 16 {
+		return nil, fmt.Errorf("data slice too short for user info: %#v", raw)
+	}
+
+	u := &UserInfo{
+		ID:                     convert.I64ValOrZero(raw[0]),
+		Email:                  convert.SValOrEmpty(raw[1]),
+		Username:               convert.SValOrEmpty(raw[2]),
+		MTSAccountCreate:       convert.I64ValOrZero(raw[3]),
+		Verified:               convert.I64ValOrZero(raw[4]) == 1,
+		VerificationLevel:      convert.I64ValOrZero(raw[5]),
+		Timezone:               convert.SValOrEmpty(raw[7]),
+		Locale:                 convert.SValOrEmpty(raw[8]),
+		Company:                convert.SValOrEmpty(raw[9]),
+		EmailVerified:          convert.I64ValOrZero(raw[10]) == 1,
+		SubAccountType:         convert.SValOrEmpty(raw[12]),
+		MTSMasterAccountCreate: convert.I64ValOrZero(raw[14]),
+		GroupID:                convert.I64ValOrZero(raw[15]),
+	}
+
+	return u, nil
+}
@@ -0,0 +1,185 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/account"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawUserInfo() []interface{} {
+	return []interface{}{
+		1234567, "user@example.com", "user", 1580401111000, 1, 3, nil,
+		"Europe/Zurich", "en_US", "bitfinex", 1, nil, nil, nil,
+		1580401111000, 0, nil, 0, nil, nil, nil, 1, 0, 0,
+	}
+}
+
+func TestUserInfoFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		u, err := account.UserInfoFromRaw([]interface{}{1234567})
+		require.NotNil(t, err)
+		require.Nil(t, u)
+	})
+
+	t.Run("master account", func(t *testing.T) {
+		u, err := account.UserInfoFromRaw(rawUserInfo())
+		require.Nil(t, err)
+
+		expected := &account.UserInfo{
+			ID:                     1234567,
+			Email:                  "user@example.com",
+			Username:               "user",
+			MTSAccountCreate:       1580401111000,
+			Verified:               true,
+			VerificationLevel:      3,
+			Timezone:               "Europe/Zurich",
+			Locale:                 "en_US",
+			Company:                "bitfinex",
+			EmailVerified:          true,
+			MTSMasterAccountCreate: 1580401111000,
+		}
+
+		assert.Equal(t, expected, u)
+		assert.False(t, u.SubAccount())
+	})
+
+	t.Run("sub account", func(t *testing.T) {
+		raw := rawUserInfo()
+		raw[10], raw[12], raw[15] = 0, "sub", 42
+
+		u, err := account.UserInfoFromRaw(raw)
+		require.Nil(t, err)
+		assert.False(t, u.EmailVerified)
+		assert.True(t, u.SubAccount())
+		assert.Equal(t, int64(42), u.GroupID)
+	})
+}
+
+func rawSummary() []interface{} {
+	return []interface{}{
+		nil, nil, nil, nil,
+		[]interface{}{
+			[]interface{}{0.001, 0.001, 0.001, nil, nil, -0.0002},
+			[]interface{}{0.002, 0.002, 0.002, nil, nil, 0.00065},
+		},
+		[]interface{}{
+			map[string]interface{}{"curr": "BTC", "vol": 1.5, "vol_maker": 0.5},
+			map[string]interface{}{"curr": "Total (USD)", "vol": 52000.25, "vol_maker": 12000.0},
+		},
+		nil, nil, nil,
+		map[string]interface{}{"leo_lev": 1.0, "leo_amount_avg": 10.5},
+	}
+}
+
+func TestSummaryFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		s, err := account.SummaryFromRaw([]interface{}{nil, nil})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("invalid fees", func(t *testing.T) {
+		raw := rawSummary()
+		raw[4] = []interface{}{[]interface{}{0.001}}
+
+		s, err := account.SummaryFromRaw(raw)
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		s, err := account.SummaryFromRaw(rawSummary())
+		require.Nil(t, err)
+
+		expected := &account.Summary{
+			Fees: account.Fees{
+				MakerFee:         0.001,
+				DerivRebate:      -0.0002,
+				TakerFeeToCrypto: 0.002,
+				TakerFeeToStable: 0.002,
+				TakerFeeToFiat:   0.002,
+				DerivTakerFee:    0.00065,
+			},
+			Volume: []account.Volume{
+				{Currency: "BTC", Volume: 1.5, VolumeMaker: 0.5},
+				{Currency: "Total (USD)", Volume: 52000.25, VolumeMaker: 12000},
+			},
+			LeoLevel:     1,
+			LeoAmountAvg: 10.5,
+		}
+
+		assert.Equal(t, expected, s)
+		assert.Equal(t, 52000.25, s.TotalVolume())
+	})
+
+	t.Run("fees only", func(t *testing.T) {
+		s, err := account.SummaryFromRaw(rawSummary()[:5])
+		require.Nil(t, err)
+		assert.Empty(t, s.Volume)
+		assert.Equal(t, 0.0, s.TotalVolume())
+		assert.Equal(t, int64(0), s.LeoLevel)
+	})
+}
+
+func TestPermissionsFromRaw(t *testing.T) {
+	t.Run("invalid permission", func(t *testing.T) {
+		p, err := account.PermissionsFromRaw([]interface{}{[]interface{}{"orders", 1}})
+		require.NotNil(t, err)
+		require.Nil(t, p)
+
+		p, err = account.PermissionsFromRaw([]interface{}{"orders"})
+		require.NotNil(t, err)
+		require.Nil(t, p)
+	})
+
+	t.Run("valid permissions", func(t *testing.T) {
+		p, err := account.PermissionsFromRaw([]interface{}{
+			[]interface{}{"account", 1, 0},
+			[]interface{}{"orders", 1, 1},
+			[]interface{}{"withdraw", 0, 0},
+		})
+		require.Nil(t, err)
+		require.Len(t, p.Permissions, 3)
+		assert.Equal(t, &account.Permission{Scope: account.ScopeOrders, Read: true, Write: true}, p.Permissions[1])
+
+		allows := func(scope account.Scope, pt common.PermissionType) bool {
+			ok, err := p.Allows(scope, pt)
+			require.Nil(t, err)
+			return ok
+		}
+		assert.True(t, allows(account.ScopeAccount, common.PermissionRead))
+		assert.False(t, allows(account.ScopeAccount, common.PermissionWrite))
+		assert.False(t, allows(account.ScopeFunding, common.PermissionRead))
+
+		missing, err := p.Missing(common.PermissionWrite, account.ScopeOrders, account.ScopeAccount, account.ScopeWithdraw)
+		require.Nil(t, err)
+		assert.Equal(t, []account.Scope{account.ScopeAccount, account.ScopeWithdraw}, missing)
+
+		missing, err = p.Missing(common.PermissionRead, account.ScopeOrders)
+		require.Nil(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("unsupported permission type", func(t *testing.T) {
+		p, err := account.PermissionsFromRaw([]interface{}{
+			[]interface{}{"orders", 1, 1},
+		})
+		require.Nil(t, err)
+
+		ok, err := p.Allows(account.ScopeOrders, common.PermissionCalc)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unsupported permission type")
+		assert.False(t, ok)
+
+		missing, err := p.Missing(common.PermissionCalc, account.ScopeOrders)
+		require.NotNil(t, err)
+		assert.Nil(t, missing)
+
+		assert.Nil(t, account.CheckPermissionType(common.PermissionRead))
+		assert.Nil(t, account.CheckPermissionType(common.PermissionWrite))
+		assert.NotNil(t, account.CheckPermissionType(common.PermissionType("")))
+	})
+}
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package account_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/account"
+)
+
+func FuzzUserInfoFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[1234567,"user@example.com","user",1580401111000,1,3,null,"Europe/Zurich","en_US","bitfinex",1,null,"sub",null,1580401111000,42]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = account.UserInfoFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSummaryFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null,null,null,null,null]`,
+		`[null,null,null,null,[[0.001,0.001,0.001,null,null,-0.0002],[0.002,0.002,0.002,null,null,0.00065]]]`,
+		`[null,null,null,null,[[0.001,0.001,0.001,null,null,-0.0002],[0.002,0.002,0.002,null,null,0.00065]],[{"curr":"Total (USD)","vol":52000.25,"vol_maker":12000}],null,null,null,{"leo_lev":1,"leo_amount_avg":10.5}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = account.SummaryFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzPermissionsFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[["orders",1]]`,
+		`[["account",1,0],["orders",1,1]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = account.PermissionsFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+// Scope provides a typed set of api key permission scopes
+type Scope string
+
+const (
+	ScopeAccount   Scope = "account"
+	ScopeHistory   Scope = "history"
+	ScopeOrders    Scope = "orders"
+	ScopePositions Scope = "positions"
+	ScopeFunding   Scope = "funding"
+	ScopeSettings  Scope = "settings"
+	ScopeWallets   Scope = "wallets"
+	ScopeWithdraw  Scope = "withdraw"
+)
+
+// Permission is read and write access of the api key to a single scope
+type Permission struct {
+	Scope Scope
+	Read  bool
+	Write bool
+}
+
+type Permissions struct {
+	Permissions []*Permission
+}
+
+// CheckPermissionType returns error for permission types which are not
+// granted per scope. Api keys have read and write access only, calc
+// endpoints do not take any scope
+func CheckPermissionType(t common.PermissionType) error {
+	switch t {
+	case common.PermissionRead, common.PermissionWrite:
+		return nil
+	}
+	return fmt.Errorf("unsupported permission type: %q, expected %q or %q", t, common.PermissionRead, common.PermissionWrite)
+}
+
+// Allows reports whether api key has the given access to scope. Returns
+// error for permission types other than read and write
+func (p *Permissions) Allows(scope Scope, t common.PermissionType) (bool, error) {
+	if err := CheckPermissionType(t); err != nil {
+		return false, err
+	}
+
+	for _, v := range p.Permissions {
+		if v.Scope != scope {
+			continue
+		}
+		if t == common.PermissionWrite {
+			return v.Write, nil
+		}
+		return v.Read, nil
+	}
+	return false, nil
+}
+
+// Missing returns scopes api key has not the given access to. Returns
+// error for permission types other than read and write
+func (p *Permissions) Missing(t common.PermissionType, scopes ...Scope) ([]Scope, error) {
+	if err := CheckPermissionType(t); err != nil {
+		return nil, err
+	}
+
+	missing := make([]Scope, 0)
+	for _, s := range scopes {
+		if ok, _ := p.Allows(s, t); !ok {
+			missing = append(missing, s)
+		}
+	}
+	return missing, nil
+}
+
+// PermissionFromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into a Permission.
+func PermissionFromRaw(raw []interface{}) (*Permission, error) {
+	if len(raw) < 3 {
+		return nil, fmt.Errorf("data slice too short for permission: %#v", raw)
+	}
+
+	p := &Permission{
+		Scope: Scope(convert.SValOrEmpty(raw[0])),
+		Read:  convert.I64ValOrZero(raw[1]) == 1,
+		Write: convert.I64ValOrZero(raw[2]) == 1,
+	}
+
+	return p, nil
+}
+
+// PermissionsFromRaw takes a raw list of values as returned from the rest
+// service and tries to convert it into Permissions.
+func PermissionsFromRaw(raw []interface{}) (*Permissions, error) {
+	ps := make([]*Permission, 0, len(raw))
+	for _, v := range raw {
+		r, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected permission slice, got: %#v", v)
+		}
+
+		p, err := PermissionFromRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return &Permissions{Permissions: ps}, nil
+}
@@ -0,0 +1,102 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// TotalVolumeCurrency is the currency of 30 day volume summed up over all
+// currencies
+const TotalVolumeCurrency = "Total (USD)"
+
+// Fees is the fee tier of the account
+type Fees struct {
+	MakerFee         float64
+	DerivRebate      float64
+	TakerFeeToCrypto float64
+	TakerFeeToStable float64
+	TakerFeeToFiat   float64
+	DerivTakerFee    float64
+}
+
+// Volume is 30 day trading volume of the account in a single currency
+type Volume struct {
+	Currency    string
+	Volume      float64
+	VolumeMaker float64
+}
+
+// Summary is 30 day trading volume and fee tier of the account
+type Summary struct {
+	Fees         Fees
+	Volume       []Volume
+	LeoLevel     int64
+	LeoAmountAvg float64
+}
+
+// TotalVolume returns 30 day volume summed up over all currencies, in USD
+func (s *Summary) TotalVolume() float64 {
+	for _, v := range s.Volume {
+		if v.Currency == TotalVolumeCurrency {
+			return v.Volume
+		}
+	}
+	return 0
+}
+
+// SummaryFromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into a Summary. Fees are sent as maker and
+// taker lists, volume as list of objects and leo info as an object, other
+// values are placeholders
+func SummaryFromRaw(raw []interface{}) (*Summary, error) {
+	if len(raw) < 5 {
+		return nil, fmt.Errorf("data slice too short for account summary: %#v", raw)
+	}
+
+	fees, _ := raw[4].([]interface{})
+	if len(fees) < 2 {
+		return nil, fmt.Errorf("unexpected fees for account summary: %#v", raw)
+	}
+
+	maker, _ := fees[0].([]interface{})
+	taker, _ := fees[1].([]interface{})
+	if len(maker) < 6 || len(taker) < 6 {
+		return nil, fmt.Errorf("unexpected fees for account summary: %#v", raw)
+	}
+
+	s := &Summary{
+		Fees: Fees{
+			MakerFee:         convert.F64ValOrZero(maker[0]),
+			DerivRebate:      convert.F64ValOrZero(maker[5]),
+			TakerFeeToCrypto: convert.F64ValOrZero(taker[0]),
+			TakerFeeToStable: convert.F64ValOrZero(taker[1]),
+			TakerFeeToFiat:   convert.F64ValOrZero(taker[2]),
+			DerivTakerFee:    convert.F64ValOrZero(taker[5]),
+		},
+		Volume: []Volume{},
+	}
+
+	if len(raw) > 5 {
+		vols, _ := raw[5].([]interface{})
+		for _, v := range vols {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			s.Volume = append(s.Volume, Volume{
+				Currency:    convert.SValOrEmpty(m["curr"]),
+				Volume:      convert.F64ValOrZero(m["vol"]),
+				VolumeMaker: convert.F64ValOrZero(m["vol_maker"]),
+			})
+		}
+	}
+
+	if len(raw) > 9 {
+		leo := convert.SiMapOrEmpty(raw[9])
+		s.LeoLevel = convert.I64ValOrZero(leo["leo_lev"])
+		s.LeoAmountAvg = convert.F64ValOrZero(leo["leo_amount_avg"])
+	}
+
+	return s, nil
+}
@@ -0,0 +1,69 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// Type provides a typed set of alert types
+type Type string
+
+const (
+	TypePrice Type = "price"
+)
+
+// Alert is a server side alert, triggered when symbol reaches the price
+type Alert struct {
+	Key    string
+	Type   Type
+	Symbol string
+	Price  float64
+}
+
+type Snapshot struct {
+	Snapshot []*Alert
+}
+
+// Key returns key api identifies alert of the given type, symbol and price by
+func Key(t Type, symbol string, price float64) string {
+	return fmt.Sprintf("%s:%s:%s", t, symbol, strconv.FormatFloat(price, 'f', -1, 64))
+}
+
+// FromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into an Alert.
+func FromRaw(raw []interface{}) (*Alert, error) {
+	if len(raw) < 4 {
+		return nil, fmt.Errorf("data slice too short for alert: %#v", raw)
+	}
+
+	a := &Alert{
+		Key:    convert.SValOrEmpty(raw[0]),
+		Type:   Type(convert.SValOrEmpty(raw[1])),
+		Symbol: convert.SValOrEmpty(raw[2]),
+		Price:  convert.F64ValOrZero(raw[3]),
+	}
+
+	return a, nil
+}
+
+// SnapshotFromRaw takes a raw list of values as returned from the rest
+// service and tries to convert it into a Snapshot.
+func SnapshotFromRaw(raw []interface{}) (*Snapshot, error) {
+	as := make([]*Alert, 0, len(raw))
+	for _, v := range raw {
+		r, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected alert slice, got: %#v", v)
+		}
+
+		a, err := FromRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, a)
+	}
+
+	return &Snapshot{Snapshot: as}, nil
+}
@@ -0,0 +1,64 @@
+package alert_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/alert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "price:tBTCUSD:560.92", alert.Key(alert.TypePrice, "tBTCUSD", 560.92))
+	assert.Equal(t, "price:tBTCUSD:600", alert.Key(alert.TypePrice, "tBTCUSD", 600))
+}
+
+func TestFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		a, err := alert.FromRaw([]interface{}{"price:tBTCUSD:560.92"})
+		require.NotNil(t, err)
+		require.Nil(t, a)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		a, err := alert.FromRaw([]interface{}{"price:tBTCUSD:560.92", "price", "tBTCUSD", 560.92, 91})
+		require.Nil(t, err)
+
+		expected := &alert.Alert{
+			Key:    "price:tBTCUSD:560.92",
+			Type:   alert.TypePrice,
+			Symbol: "tBTCUSD",
+			Price:  560.92,
+		}
+		assert.Equal(t, expected, a)
+	})
+}
+
+func TestSnapshotFromRaw(t *testing.T) {
+	t.Run("no alerts", func(t *testing.T) {
+		s, err := alert.SnapshotFromRaw([]interface{}{})
+		require.Nil(t, err)
+		assert.Empty(t, s.Snapshot)
+	})
+
+	t.Run("invalid alert", func(t *testing.T) {
+		s, err := alert.SnapshotFromRaw([]interface{}{"price:tBTCUSD:560.92"})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+
+		s, err = alert.SnapshotFromRaw([]interface{}{[]interface{}{"price:tBTCUSD:560.92"}})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("valid alerts", func(t *testing.T) {
+		s, err := alert.SnapshotFromRaw([]interface{}{
+			[]interface{}{"price:tBTCUSD:560.92", "price", "tBTCUSD", 560.92, 91},
+			[]interface{}{"price:tETHUSD:200", "price", "tETHUSD", 200.0, 100},
+		})
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+		assert.Equal(t, "tETHUSD", s.Snapshot[1].Symbol)
+		assert.Equal(t, 200.0, s.Snapshot[1].Price)
+	})
+}
@@ -0,0 +1,38 @@
+//go:build go1.18
+// +build go1.18
+
+package alert_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/alert"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["price:tBTCUSD:560.92","price","tBTCUSD",560.92,91]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = alert.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[["price:tBTCUSD:560.92","price","tBTCUSD",560.92,91]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = alert.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
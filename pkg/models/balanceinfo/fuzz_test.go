@@ -0,0 +1,37 @@
+//go:build go1.18
+// +build go1.18
+
+package balanceinfo_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/balanceinfo"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[4131.85,4131.85]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = balanceinfo.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[4131.85,4131.85]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = balanceinfo.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -44,9 +44,14 @@ func SnapshotFromRaw(symbol, precision string, raw [][]interface{}, rawNumbers i
 		return nil, fmt.Errorf("data slice too short for book snapshot: %#v", raw)
 	}
 
+	nums, ok := rawNumbers.([]interface{})
+	if !ok || len(nums) != len(raw) {
+		return nil, fmt.Errorf("raw numbers do not match book snapshot: %#v", rawNumbers)
+	}
+
 	snap := make([]*Book, len(raw))
 	for i, v := range raw {
-		b, err := FromRaw(symbol, precision, v, rawNumbers.([]interface{})[i])
+		b, err := FromRaw(symbol, precision, v, nums[i])
 		if err != nil {
 			return nil, err
 		}
@@ -68,26 +73,98 @@ func FromRaw(symbol, precision string, raw []interface{}, rawNumbers interface{}
 		return b, fmt.Errorf("raw slice too short for book, expected %d got %d: %#v", 3, len(raw), raw)
 	}
 
-	rawBook := IsRawBook(precision)
+	nums, ok := rawNumbers.([]interface{})
+	if !ok || len(nums) < len(raw) {
+		return b, fmt.Errorf("raw numbers do not match book: %#v", rawNumbers)
+	}
 
-	if len(raw) == 3 && rawBook {
-		b = rawTradingPairsBook(raw, rawNumbers)
+	e := entry{num: func(i int) json.Number { return convert.FloatToJsonNumber(nums[i]) }}
+	for i := 0; i < len(raw) && i < len(e.f); i++ {
+		e.f[i] = convert.F64ValOrZero(raw[i])
+		e.i[i] = convert.I64ValOrZero(raw[i])
 	}
 
-	if len(raw) == 3 && !rawBook {
-		b = tradingPairsBook(raw, rawNumbers)
+	bk := newBook(symbol, precision, len(raw), &e)
+	return &bk, nil
+}
+
+// FromJSON creates a new book object from raw json of book entry. Entry is
+// parsed in a single pass, without decoding it into intermediate values,
+// see FromRaw for supported signatures
+func FromJSON(symbol, precision string, data []byte) (*Book, error) {
+	b := &Book{}
+	if err := fillFromJSON(b, symbol, precision, data); err != nil {
+		return nil, err
 	}
+	return b, nil
+}
 
-	if len(raw) >= 4 && rawBook {
-		b = rawFundingPairsBook(raw, rawNumbers)
+// SnapshotFromJSON creates book snapshot from raw json of book entries, see FromJSON
+func SnapshotFromJSON(symbol, precision string, data []byte) (*Snapshot, error) {
+	rawEntries, err := convert.SplitArray(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawEntries) == 0 {
+		return nil, fmt.Errorf("data slice too short for book snapshot: %s", data)
 	}
 
-	if len(raw) >= 4 && !rawBook {
-		b = fundingPairsBook(raw, rawNumbers)
+	// entries are allocated at once, snapshots can be hundreds of them long
+	books := make([]Book, len(rawEntries))
+	snap := make([]*Book, len(rawEntries))
+	for i, v := range rawEntries {
+		if err := fillFromJSON(&books[i], symbol, precision, v); err != nil {
+			return nil, err
+		}
+		snap[i] = &books[i]
 	}
 
-	b.Symbol = symbol
+	return &Snapshot{Snapshot: snap}, nil
+}
+
+func fillFromJSON(b *Book, symbol, precision string, data []byte) error {
+	var buf [8][]byte
+	raw, err := convert.SplitArray(buf[:0], data)
+	if err != nil {
+		return err
+	}
+	if len(raw) < 3 {
+		return fmt.Errorf("raw slice too short for book, expected %d got %d: %s", 3, len(raw), data)
+	}
+
+	e := entry{num: func(i int) json.Number { return convert.BytesToJsonNumber(raw[i]) }}
+	for i := 0; i < len(raw) && i < len(e.f); i++ {
+		e.f[i] = convert.F64BytesOrZero(raw[i])
+		e.i[i] = convert.I64BytesOrZero(raw[i])
+	}
+
+	*b = newBook(symbol, precision, len(raw), &e)
+	return nil
+}
+
+// entry holds converted values of book entry fields. Original representation
+// of numbers, used by checksums, is converted only for fields which need it
+type entry struct {
+	f   [4]float64
+	i   [4]int64
+	num func(i int) json.Number
+}
+
+func newBook(symbol, precision string, n int, e *entry) (b Book) {
+	rawBook := IsRawBook(precision)
+
+	switch {
+	case n == 3 && rawBook:
+		b = rawTradingPairsBook(e)
+	case n == 3:
+		b = tradingPairsBook(e)
+	case rawBook:
+		b = rawFundingPairsBook(e)
+	default:
+		b = fundingPairsBook(e)
+	}
 
+	b.Symbol = symbol
 	return
 }
 
@@ -105,16 +182,15 @@ func FromWSRaw(symbol, precision string, data []interface{}) (interface{}, error
 	return FromRaw(symbol, precision, data, data)
 }
 
-func rawTradingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
+func rawTradingPairsBook(e *entry) Book {
 	// [ ORDER_ID, PRICE, AMOUNT ] - raw trading pairs signature
 	var (
 		side   common.OrderSide
 		action BookAction
 	)
 
-	rawNumSlice := rawNumbers.([]interface{})
-	price := convert.F64ValOrZero(raw[1])
-	amount := convert.F64ValOrZero(raw[2])
+	price := e.f[1]
+	amount := e.f[2]
 
 	if amount > 0 {
 		side = common.Bid
@@ -128,34 +204,27 @@ func rawTradingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
 		action = BookEntry
 	}
 
-	return &Book{
+	return Book{
 		Price:       math.Abs(price),
-		PriceJsNum:  convert.FloatToJsonNumber(rawNumSlice[1]),
+		PriceJsNum:  e.num(1),
 		Amount:      math.Abs(amount),
-		AmountJsNum: convert.FloatToJsonNumber(rawNumSlice[2]),
+		AmountJsNum: e.num(2),
 		Side:        side,
 		Action:      action,
-		ID:          convert.I64ValOrZero(raw[0]),
+		ID:          e.i[0],
 	}
 }
 
-func tradingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
+func tradingPairsBook(e *entry) Book {
 	// [ PRICE, COUNT, AMOUNT ] - trading pairs signature
 	var (
-		price    float64
-		count    int64
-		priceNum json.Number
-		side     common.OrderSide
-		action   BookAction
+		side   common.OrderSide
+		action BookAction
 	)
 
-	rawNumSlice := rawNumbers.([]interface{})
-	amount := convert.F64ValOrZero(raw[2])
-	amountNum := convert.FloatToJsonNumber(rawNumSlice[2])
-
-	price = convert.F64ValOrZero(raw[0])
-	priceNum = convert.FloatToJsonNumber(rawNumSlice[0])
-	count = convert.I64ValOrZero(raw[1])
+	price := e.f[0]
+	count := e.i[1]
+	amount := e.f[2]
 
 	if amount > 0 {
 		side = common.Bid
@@ -169,39 +238,37 @@ func tradingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
 		action = BookEntry
 	}
 
-	return &Book{
+	return Book{
 		Price:       math.Abs(price),
-		PriceJsNum:  priceNum,
+		PriceJsNum:  e.num(0),
 		Count:       count,
 		Amount:      math.Abs(amount),
-		AmountJsNum: amountNum,
+		AmountJsNum: e.num(2),
 		Side:        side,
 		Action:      action,
 	}
 }
 
-func rawFundingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
+func rawFundingPairsBook(e *entry) Book {
 	// [ ORDER_ID, PERIOD, RATE, AMOUNT ] - raw funding pairs signature
-	rawNumSlice := rawNumbers.([]interface{})
 
-	return &Book{
-		ID:          convert.I64ValOrZero(raw[0]),
-		Period:      convert.I64ValOrZero(raw[1]),
-		Rate:        convert.F64ValOrZero(raw[2]),
-		Amount:      convert.F64ValOrZero(raw[3]),
-		AmountJsNum: convert.FloatToJsonNumber(rawNumSlice[3]),
+	return Book{
+		ID:          e.i[0],
+		Period:      e.i[1],
+		Rate:        e.f[2],
+		Amount:      e.f[3],
+		AmountJsNum: e.num(3),
 	}
 }
 
-func fundingPairsBook(raw []interface{}, rawNumbers interface{}) *Book {
+func fundingPairsBook(e *entry) Book {
 	// [ RATE, PERIOD, COUNT, AMOUNT ], - funding pairs signature
-	rawNumSlice := rawNumbers.([]interface{})
-
-	return &Book{
-		Rate:        convert.F64ValOrZero(raw[0]),
-		Period:      convert.I64ValOrZero(raw[1]),
-		Count:       convert.I64ValOrZero(raw[2]),
-		Amount:      convert.F64ValOrZero(raw[3]),
-		AmountJsNum: convert.FloatToJsonNumber(rawNumSlice[3]),
+
+	return Book{
+		Rate:        e.f[0],
+		Period:      e.i[1],
+		Count:       e.i[2],
+		Amount:      e.f[3],
+		AmountJsNum: e.num(3),
 	}
 }
@@ -1,8 +1,11 @@
 package book_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/book"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
 	"github.com/stretchr/testify/assert"
@@ -108,3 +111,119 @@ func TestNewBookFromRaw(t *testing.T) {
 		assert.Equal(t, expected, b)
 	})
 }
+
+// decodeJSON unmarshals payload the way websocket clients do, as floats and as json numbers
+func decodeJSON(t testing.TB, pld []byte) ([]interface{}, []interface{}) {
+	var raw, nums []interface{}
+	require.Nil(t, json.Unmarshal(pld, &raw))
+
+	d := json.NewDecoder(bytes.NewReader(pld))
+	d.UseNumber()
+	require.Nil(t, d.Decode(&nums))
+	return raw, nums
+}
+
+func TestFromJSON(t *testing.T) {
+	cases := map[string]struct {
+		precision string
+		pld       string
+	}{
+		"trading":           {precision: "P0", pld: `[98169.99541156,2,0.000202]`},
+		"trading removal":   {precision: "P0", pld: `[8744.9, 0, -1]`},
+		"raw trading":       {precision: "R0", pld: `[34006738527,8744.9,-0.25603413]`},
+		"funding":           {precision: "P0", pld: `[0.0003301,30,1,-3862.874]`},
+		"raw funding":       {precision: "R0", pld: `[645902785,30,0.0003301,-3862.874]`},
+		"exponent notation": {precision: "P0", pld: `[1.5e-7,2,1E+2]`},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			raw, nums := decodeJSON(t, []byte(v.pld))
+			expected, err := book.FromRaw("tBTCUSD", v.precision, raw, nums)
+			require.Nil(t, err)
+
+			got, err := book.FromJSON("tBTCUSD", v.precision, []byte(v.pld))
+			require.Nil(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+
+	t.Run("snapshot", func(t *testing.T) {
+		pld := []byte(`[[8744.9,2,0.25603413],[8745,1,-0.1],[8746,1,-2e-8]]`)
+		raw, nums := decodeJSON(t, pld)
+		expected, err := book.SnapshotFromRaw("tBTCUSD", "P0", convert.ToInterfaceArray(raw), nums)
+		require.Nil(t, err)
+
+		got, err := book.SnapshotFromJSON("tBTCUSD", "P0", pld)
+		require.Nil(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("invalid payloads", func(t *testing.T) {
+		for _, pld := range []string{`[]`, `[1,2]`, `[1,2,`, `{"a":1}`, `[1,2,3]x`} {
+			b, err := book.FromJSON("tBTCUSD", "P0", []byte(pld))
+			assert.NotNil(t, err, pld)
+			assert.Nil(t, b)
+		}
+
+		for _, pld := range []string{`[]`, `[[1,2]]`, `[1,2,3]`} {
+			s, err := book.SnapshotFromJSON("tBTCUSD", "P0", []byte(pld))
+			assert.NotNil(t, err, pld)
+			assert.Nil(t, s)
+		}
+	})
+}
+
+var (
+	benchUpdate   = []byte(`[7254.7,3,0.30000000]`)
+	benchSnapshot = func() []byte {
+		b := []byte("[")
+		for i := 0; i < 50; i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, `[7254.7,3,-0.30000000]`...)
+		}
+		return append(b, ']')
+	}()
+)
+
+// BenchmarkFromRaw decodes book update twice, as floats and as json numbers,
+// as needed by FromRaw
+func BenchmarkFromRaw(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		raw, nums := decodeJSON(b, benchUpdate)
+		if _, err := book.FromRaw("tBTCUSD", "P0", raw, nums); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromJSON(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := book.FromJSON("tBTCUSD", "P0", benchUpdate); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromRaw(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		raw, nums := decodeJSON(b, benchSnapshot)
+		if _, err := book.SnapshotFromRaw("tBTCUSD", "P0", convert.ToInterfaceArray(raw), nums); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromJSON(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := book.SnapshotFromJSON("tBTCUSD", "P0", benchSnapshot); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
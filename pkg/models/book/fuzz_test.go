@@ -0,0 +1,74 @@
+//go:build go1.18
+// +build go1.18
+
+package book_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/book"
+)
+
+var seeds = []struct{ precision, data string }{
+	{"P0", `[]`},
+	{"P0", `[null]`},
+	{"P0", `[7254.7,3,0.3]`},
+	{"P0", `[0.0003,2,3,-1000]`},
+	{"R0", `[34006738527,7254.7,0.3]`},
+	{"R0", `[34006738527,2,0.0003,-1000]`},
+	{"P0", `[[7254.7,3,0.3],[7254.8,0,-1]]`},
+	{"R0", `[[34006738527,7254.7,0.3]]`},
+}
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed.precision, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, precision, data string) {
+		raw, nums := fuzzutil.DecodeNumbers(t, data)
+		_, _ = book.FromRaw("tBTCUSD", precision, raw, nums)
+		_, _ = book.FromRaw("tBTCUSD", precision, raw, nil)
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed.precision, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, precision, data string) {
+		raw, nums := fuzzutil.DecodeNumbers(t, data)
+		_, _ = book.SnapshotFromRaw("tBTCUSD", precision, convert.ToInterfaceArray(raw), nums)
+		_, _ = book.SnapshotFromRaw("tBTCUSD", precision, convert.ToInterfaceArray(raw), raw)
+	})
+}
+
+func FuzzFromWSRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed.precision, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, precision, data string) {
+		raw, nums := fuzzutil.DecodeNumbers(t, data)
+		_, _ = book.FromWSRaw("tBTCUSD", precision, raw)
+		_, _ = book.FromWSRaw("tBTCUSD", precision, nums)
+	})
+}
+
+func FuzzFromJSON(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed.precision, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, precision, data string) {
+		_, _ = book.FromJSON("tBTCUSD", precision, []byte(data))
+	})
+}
+
+func FuzzSnapshotFromJSON(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed.precision, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, precision, data string) {
+		_, _ = book.SnapshotFromJSON("tBTCUSD", precision, []byte(data))
+	})
+}
@@ -0,0 +1,98 @@
+package calc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// Direction of an order available balance is calculated for
+type Direction int
+
+const (
+	Buy  Direction = 1
+	Sell Direction = -1
+)
+
+// BalanceType provides a typed set of balances available balance can be
+// calculated for
+type BalanceType string
+
+const (
+	BalanceExchange BalanceType = "EXCHANGE"
+	BalanceMargin   BalanceType = "MARGIN"
+	BalanceDeriv    BalanceType = "DERIV"
+	BalanceFunding  BalanceType = "FUNDING"
+)
+
+// AvailableBalanceRequest asks for maximum amount of an order, or a funding
+// offer, given current wallet and margin state. Zero rate and leverage are
+// left out
+type AvailableBalanceRequest struct {
+	Symbol string
+	Dir    Direction
+	// order price, or funding rate
+	Rate float64
+	Type BalanceType
+	// leverage, derivatives only
+	Lev float64
+}
+
+// AvailableBalance is a result of available balance calculation
+type AvailableBalance struct {
+	Symbol string
+	Dir    Direction
+	Type   BalanceType
+	// as returned by api
+	Amount float64
+}
+
+func (r *AvailableBalanceRequest) ToJSON() ([]byte, error) {
+	aux := struct {
+		Symbol string      `json:"symbol"`
+		Dir    Direction   `json:"dir,omitempty"`
+		Rate   string      `json:"rate,omitempty"`
+		Type   BalanceType `json:"type"`
+		Lev    string      `json:"lev,omitempty"`
+	}{
+		Symbol: r.Symbol,
+		Dir:    r.Dir,
+		Type:   r.Type,
+	}
+	if r.Rate != 0 {
+		aux.Rate = strconv.FormatFloat(r.Rate, 'f', -1, 64)
+	}
+	if r.Lev != 0 {
+		aux.Lev = strconv.FormatFloat(r.Lev, 'f', -1, 64)
+	}
+	return json.Marshal(aux)
+}
+
+// Size returns available amount regardless of its sign, to be used along
+// with direction when sizing orders
+func (a *AvailableBalance) Size() float64 {
+	if a.Amount < 0 {
+		return -a.Amount
+	}
+	return a.Amount
+}
+
+// AvailableBalanceFromRaw takes the raw list of values as returned from the
+// rest service in response to the given request and tries to convert it into
+// an AvailableBalance.
+func AvailableBalanceFromRaw(r *AvailableBalanceRequest, raw []interface{}) (*AvailableBalance, error) {
+	if len(raw) < 1 {
+		return nil, fmt.Errorf("data slice too short for available balance: %#v", raw)
+	}
+
+	ab := &AvailableBalance{
+		Symbol: r.Symbol,
+		Dir:    r.Dir,
+		Type:   r.Type,
+		Amount: convert.F64ValOrZero(raw[0]),
+	}
+
+	return ab, nil
+}
@@ -0,0 +1,60 @@
+package calc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Wallet identifies wallet balance to recalculate
+type Wallet struct {
+	Type     string
+	Currency string
+}
+
+// Request asks api to recalculate given margin, funding, position and
+// wallet balances. Results arrive as miu, fiu, pu and wu updates
+type Request struct {
+	MarginBase     bool
+	MarginSymbols  []string
+	FundingSymbols []string
+	Positions      []string
+	Wallets        []Wallet
+}
+
+// Empty returns true if there is nothing to recalculate
+func (r *Request) Empty() bool {
+	return len(r.ops()) == 0
+}
+
+func (r *Request) ops() (ops [][]string) {
+	if r.MarginBase {
+		ops = append(ops, []string{"margin_base"})
+	}
+	for _, s := range r.MarginSymbols {
+		ops = append(ops, []string{"margin_sym_" + s})
+	}
+	for _, s := range r.FundingSymbols {
+		ops = append(ops, []string{"funding_sym_" + s})
+	}
+	for _, s := range r.Positions {
+		ops = append(ops, []string{"position_" + s})
+	}
+	for _, w := range r.Wallets {
+		ops = append(ops, []string{fmt.Sprintf("wallet_%s_%s", w.Type, w.Currency)})
+	}
+	return
+}
+
+func (r *Request) ToJSON() ([]byte, error) {
+	return json.Marshal(r.ops())
+}
+
+// MarshalJSON converts the calc request into the format required by the
+// bitfinex websocket service.
+func (r *Request) MarshalJSON() ([]byte, error) {
+	b, err := r.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("[0, \"calc\", null, %s]", string(b))), nil
+}
@@ -0,0 +1,73 @@
+package calc_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest(t *testing.T) {
+	t.Run("MarshalJSON", func(t *testing.T) {
+		r := calc.Request{
+			MarginBase:     true,
+			MarginSymbols:  []string{"tBTCUSD"},
+			FundingSymbols: []string{"fUSD"},
+			Positions:      []string{"tETHUSD"},
+			Wallets:        []calc.Wallet{{Type: "margin", Currency: "USD"}},
+		}
+		got, err := r.MarshalJSON()
+
+		require.Nil(t, err)
+
+		expected := `[0, "calc", null, [["margin_base"],["margin_sym_tBTCUSD"],["funding_sym_fUSD"],["position_tETHUSD"],["wallet_margin_USD"]]]`
+		assert.Equal(t, expected, string(got))
+		assert.False(t, r.Empty())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		r := calc.Request{}
+		assert.True(t, r.Empty())
+	})
+}
+
+func TestAvailableBalanceRequest(t *testing.T) {
+	t.Run("ToJSON", func(t *testing.T) {
+		r := calc.AvailableBalanceRequest{Symbol: "tBTCF0:USTF0", Dir: calc.Sell, Rate: 34000.5, Type: calc.BalanceDeriv, Lev: 10}
+		got, err := r.ToJSON()
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"symbol":"tBTCF0:USTF0","dir":-1,"rate":"34000.5","type":"DERIV","lev":"10"}`, string(got))
+	})
+
+	t.Run("ToJSON without rate and leverage", func(t *testing.T) {
+		r := calc.AvailableBalanceRequest{Symbol: "fUSD", Dir: calc.Buy, Type: calc.BalanceFunding}
+		got, err := r.ToJSON()
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"symbol":"fUSD","dir":1,"type":"FUNDING"}`, string(got))
+	})
+}
+
+func TestAvailableBalanceFromRaw(t *testing.T) {
+	r := &calc.AvailableBalanceRequest{Symbol: "tBTCUSD", Dir: calc.Sell, Rate: 34000.5, Type: calc.BalanceMargin}
+
+	t.Run("insufficient arguments", func(t *testing.T) {
+		got, err := calc.AvailableBalanceFromRaw(r, []interface{}{})
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		got, err := calc.AvailableBalanceFromRaw(r, []interface{}{-1.25})
+		require.Nil(t, err)
+
+		expected := &calc.AvailableBalance{
+			Symbol: "tBTCUSD",
+			Dir:    calc.Sell,
+			Type:   calc.BalanceMargin,
+			Amount: -1.25,
+		}
+		assert.Equal(t, expected, got)
+		assert.Equal(t, 1.25, got.Size())
+	})
+}
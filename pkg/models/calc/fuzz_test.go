@@ -0,0 +1,25 @@
+//go:build go1.18
+// +build go1.18
+
+package calc_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+)
+
+func FuzzAvailableBalanceFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[-1.25]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		r := &calc.AvailableBalanceRequest{Symbol: "tBTCUSD", Dir: calc.Buy, Type: calc.BalanceExchange}
+		_, _ = calc.AvailableBalanceFromRaw(r, fuzzutil.Decode(t, data))
+	})
+}
@@ -0,0 +1,56 @@
+//go:build go1.18
+// +build go1.18
+
+package candle_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/candle"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = candle.FromRaw(symbol, common.OneMinute, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3],[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = candle.SnapshotFromRaw(symbol, common.OneMinute, snap)
+	})
+}
+
+func FuzzFromWSRaw(f *testing.F) {
+	for _, seed := range []struct{ key, data string }{
+		{"trade:1m:tBTCUSD", `[]`},
+		{"trade:1m:tBTCUSD", `[null]`},
+		{"trade:1m:tBTCUSD", `[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]`},
+		{"trade:1m:tBTCUSD", `[[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]]`},
+		{"trade:1m", `[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]`},
+	} {
+		f.Add(seed.key, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, key, data string) {
+		_, _ = candle.FromWSRaw(key, fuzzutil.Decode(t, data))
+	})
+}
@@ -29,6 +29,7 @@ const (
 	OrderTypeExchangeStopLimit                     = "EXCHANGE STOP LIMIT"
 	PermissionRead                                 = "r"
 	PermissionWrite                                = "w"
+	PermissionCalc                                 = "calc"
 	FundingPrefix                                  = "f"
 	TradingPrefix                                  = "t"
 	FundingSizeKey                StatKey          = "funding.size"
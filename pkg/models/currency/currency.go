@@ -1,6 +1,11 @@
 package currency
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
 
 type Conf struct {
 	Currency  string
@@ -33,93 +38,83 @@ type RawConf struct {
 	Data    interface{}
 }
 
-func parseLabelMap(config map[string]Conf, raw []interface{}) {
-	for _, rawLabel := range raw {
-		data := rawLabel.([]interface{})
-		cur := data[0].(string)
-		if val, ok := config[cur]; ok {
-			// add value
-			val.Label = data[1].(string)
-			config[cur] = val
-		} else {
-			// create new empty config instance
-			cfg := Conf{}
-			cfg.Label = data[1].(string)
-			cfg.Currency = cur
-			config[cur] = cfg
-		}
+// entry returns currency and value of a [CURRENCY, VALUE] mapping entry
+func entry(mapping ConfigMapping, raw interface{}) (string, interface{}, error) {
+	data, ok := raw.([]interface{})
+	if !ok || len(data) < 2 {
+		return "", nil, fmt.Errorf("unexpected %s entry: %#v", mapping, raw)
+	}
+
+	cur, ok := data[0].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected %s currency: %#v", mapping, data[0])
 	}
+	return cur, data[1], nil
 }
 
-func parseSymbMap(config map[string]Conf, raw []interface{}) {
-	for _, rawLabel := range raw {
-		data := rawLabel.([]interface{})
-		cur := data[0].(string)
-		if val, ok := config[cur]; ok {
-			// add value
-			val.Symbol = data[1].(string)
-			config[cur] = val
-		} else {
-			// create new empty config instance
-			cfg := Conf{}
-			cfg.Symbol = data[1].(string)
-			cfg.Currency = cur
-			config[cur] = cfg
-		}
+// conf returns config of given currency, empty one if there is none yet
+func conf(config map[string]Conf, cur string) Conf {
+	if val, ok := config[cur]; ok {
+		return val
 	}
+	return Conf{Currency: cur}
 }
 
-func parseUnitMap(config map[string]Conf, raw []interface{}) {
+func parseStringMap(config map[string]Conf, mapping ConfigMapping, raw []interface{}, set func(*Conf, string)) error {
 	for _, rawLabel := range raw {
-		data := rawLabel.([]interface{})
-		cur := data[0].(string)
-		if val, ok := config[cur]; ok {
-			// add value
-			val.Unit = data[1].(string)
-			config[cur] = val
-		} else {
-			// create new empty config instance
-			cfg := Conf{}
-			cfg.Unit = data[1].(string)
-			cfg.Currency = cur
-			config[cur] = cfg
+		cur, val, err := entry(mapping, rawLabel)
+		if err != nil {
+			return err
 		}
+
+		cfg := conf(config, cur)
+		set(&cfg, convert.SValOrEmpty(val))
+		config[cur] = cfg
 	}
+	return nil
 }
 
-func parseExplorerMap(config map[string]Conf, raw []interface{}) {
+func parseExplorerMap(config map[string]Conf, raw []interface{}) error {
 	for _, rawLabel := range raw {
-		data := rawLabel.([]interface{})
-		cur := data[0].(string)
-		explorers := data[1].([]interface{})
-		var cfg Conf
-		if val, ok := config[cur]; ok {
-			cfg = val
-		} else {
-			// create new empty config instance
-			cc := Conf{}
-			cc.Currency = cur
-			cfg = cc
+		cur, val, err := entry(ExplorerMap, rawLabel)
+		if err != nil {
+			return err
+		}
+
+		explorers, ok := val.([]interface{})
+		if !ok || len(explorers) < 3 {
+			return fmt.Errorf("unexpected %s explorers: %#v", ExplorerMap, val)
 		}
-		ec := ExplorerConf{
-			explorers[0].(string),
-			explorers[1].(string),
-			explorers[2].(string),
+
+		cfg := conf(config, cur)
+		cfg.Explorers = ExplorerConf{
+			convert.SValOrEmpty(explorers[0]),
+			convert.SValOrEmpty(explorers[1]),
+			convert.SValOrEmpty(explorers[2]),
 		}
-		cfg.Explorers = ec
 		config[cur] = cfg
 	}
+	return nil
 }
 
-func parseExchangeMap(config map[string]Conf, raw []interface{}) {
+func parseExchangeMap(config map[string]Conf, raw []interface{}) error {
 	for _, rs := range raw {
-		symbol := rs.(string)
-		var base, quote string
+		symbol, ok := rs.(string)
+		if !ok {
+			return fmt.Errorf("unexpected %s pair: %#v", ExchangeMap, rs)
+		}
 
+		var base, quote string
 		if len(symbol) > 6 {
-			base = strings.Split(symbol, ":")[0]
-			quote = strings.Split(symbol, ":")[1]
+			parts := strings.SplitN(symbol, ":", 2)
+			if len(parts) < 2 {
+				return fmt.Errorf("unexpected %s pair: %s", ExchangeMap, symbol)
+			}
+			base, quote = parts[0], parts[1]
 		} else {
+			if len(symbol) < 3 {
+				return fmt.Errorf("unexpected %s pair: %s", ExchangeMap, symbol)
+			}
 			base = symbol[3:]
 			quote = symbol[:3]
 		}
@@ -136,27 +131,40 @@ func parseExchangeMap(config map[string]Conf, raw []interface{}) {
 			config[quote] = val
 		}
 	}
+	return nil
 }
 
 func FromRaw(raw []RawConf) ([]Conf, error) {
 	configMap := make(map[string]Conf)
 	for _, r := range raw {
+		var parse func([]interface{}) error
 		switch ConfigMapping(r.Mapping) {
 		case LabelMap:
-			data := r.Data.([]interface{})
-			parseLabelMap(configMap, data)
+			parse = func(data []interface{}) error {
+				return parseStringMap(configMap, LabelMap, data, func(c *Conf, v string) { c.Label = v })
+			}
 		case SymbolMap:
-			data := r.Data.([]interface{})
-			parseSymbMap(configMap, data)
+			parse = func(data []interface{}) error {
+				return parseStringMap(configMap, SymbolMap, data, func(c *Conf, v string) { c.Symbol = v })
+			}
 		case UnitMap:
-			data := r.Data.([]interface{})
-			parseUnitMap(configMap, data)
+			parse = func(data []interface{}) error {
+				return parseStringMap(configMap, UnitMap, data, func(c *Conf, v string) { c.Unit = v })
+			}
 		case ExplorerMap:
-			data := r.Data.([]interface{})
-			parseExplorerMap(configMap, data)
+			parse = func(data []interface{}) error { return parseExplorerMap(configMap, data) }
 		case ExchangeMap:
-			data := r.Data.([]interface{})
-			parseExchangeMap(configMap, data)
+			parse = func(data []interface{}) error { return parseExchangeMap(configMap, data) }
+		default:
+			continue
+		}
+
+		data, ok := r.Data.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s data: %#v", r.Mapping, r.Data)
+		}
+		if err := parse(data); err != nil {
+			return nil, err
 		}
 	}
 
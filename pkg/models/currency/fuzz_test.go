@@ -0,0 +1,36 @@
+//go:build go1.18
+// +build go1.18
+
+package currency_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/currency"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []struct{ mapping, data string }{
+		{string(currency.LabelMap), `null`},
+		{string(currency.LabelMap), `[["BTC","Bitcoin"]]`},
+		{string(currency.SymbolMap), `[["UST","USDt"]]`},
+		{string(currency.UnitMap), `[["IOT","Mi|MegaIOTA"]]`},
+		{string(currency.ExplorerMap), `[["BTC",["https://blockchain.info","https://blockchain.info/address/VAL","https://blockchain.info/tx/VAL"]]]`},
+		{string(currency.ExchangeMap), `["BTCUSD","BTCF0:USTF0","AB"]`},
+		{"unknown", `null`},
+	} {
+		f.Add(seed.mapping, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, mapping, data string) {
+		var raw interface{}
+		if err := json.Unmarshal([]byte(data), &raw); err != nil {
+			t.Skip()
+		}
+
+		_, _ = currency.FromRaw([]currency.RawConf{
+			{Mapping: string(currency.LabelMap), Data: []interface{}{[]interface{}{"BTC", "Bitcoin"}}},
+			{Mapping: mapping, Data: raw},
+		})
+	})
+}
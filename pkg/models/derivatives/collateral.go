@@ -0,0 +1,30 @@
+package derivatives
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// CollateralLimits is a range collateral of derivative position can be set within
+type CollateralLimits struct {
+	Symbol        string
+	MinCollateral float64
+	MaxCollateral float64
+}
+
+// CollateralLimitsFromRaw takes the raw list of values as returned from the
+// collateral limits endpoint and tries to convert it into a CollateralLimits
+func CollateralLimitsFromRaw(symbol string, raw []interface{}) (*CollateralLimits, error) {
+	if len(raw) < 2 {
+		return nil, fmt.Errorf("data slice too short for collateral limits: %#v", raw)
+	}
+
+	cl := &CollateralLimits{
+		Symbol:        symbol,
+		MinCollateral: convert.F64ValOrZero(raw[0]),
+		MaxCollateral: convert.F64ValOrZero(raw[1]),
+	}
+
+	return cl, nil
+}
@@ -0,0 +1,29 @@
+package derivatives_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/derivatives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollateralLimitsFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		cl, err := derivatives.CollateralLimitsFromRaw("tBTCF0:USTF0", []interface{}{0.5})
+		require.NotNil(t, err)
+		require.Nil(t, cl)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		cl, err := derivatives.CollateralLimitsFromRaw("tBTCF0:USTF0", []interface{}{0.5, 120.25})
+		require.Nil(t, err)
+
+		expected := &derivatives.CollateralLimits{
+			Symbol:        "tBTCF0:USTF0",
+			MinCollateral: 0.5,
+			MaxCollateral: 120.25,
+		}
+		assert.Equal(t, expected, cl)
+	})
+}
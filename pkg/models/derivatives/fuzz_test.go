@@ -0,0 +1,66 @@
+//go:build go1.18
+// +build go1.18
+
+package derivatives_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/derivatives"
+)
+
+func FuzzFromWsRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCF0:USTF0", `[]`},
+		{"tBTCF0:USTF0", `[null]`},
+		{"tBTCF0:USTF0", `[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = derivatives.FromWsRaw(symbol, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCF0:USTF0",1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = derivatives.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[["tBTCF0:USTF0",1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = derivatives.SnapshotFromRaw(snap)
+	})
+}
+
+func FuzzCollateralLimitsFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[0.5,120.25]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = derivatives.CollateralLimitsFromRaw("tBTCF0:USTF0", fuzzutil.Decode(t, data))
+	})
+}
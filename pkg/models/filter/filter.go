@@ -0,0 +1,105 @@
+package filter
+
+import "strings"
+
+// Available authenticated channel filter prefixes
+const (
+	Trading = "trading"
+	Funding = "funding"
+	Wallet  = "wallet"
+	Algo    = "algo"
+	Balance = "balance"
+	Notify  = "notify"
+)
+
+// Builder assembles the list of filters sent with an auth request. Once
+// filters are applied, the authenticated channel will only deliver messages
+// matching at least one of them. See https://docs.bitfinex.com/docs/ws-auth
+type Builder struct {
+	filters []string
+	seen    map[string]bool
+}
+
+// New returns pointer to an empty filter Builder
+func New() *Builder {
+	return &Builder{
+		filters: make([]string, 0),
+		seen:    make(map[string]bool),
+	}
+}
+
+// Trading adds trading filters. Without symbols, all trading messages are
+// delivered, otherwise only the ones of given symbols, e.g. "trading-tBTCUSD"
+func (b *Builder) Trading(symbols ...string) *Builder {
+	return b.addScoped(Trading, symbols...)
+}
+
+// Funding adds funding filters. Without symbols, all funding messages are
+// delivered, otherwise only the ones of given symbols, e.g. "funding-fUSD"
+func (b *Builder) Funding(symbols ...string) *Builder {
+	return b.addScoped(Funding, symbols...)
+}
+
+// Wallet adds filter for all wallet messages
+func (b *Builder) Wallet() *Builder {
+	return b.add(Wallet)
+}
+
+// WalletCurrency adds wallet filter scoped to wallet type and currency,
+// e.g. WalletCurrency("exchange", "USD") yields "wallet-exchange-USD"
+func (b *Builder) WalletCurrency(walletType, currency string) *Builder {
+	return b.add(join(Wallet, walletType, currency))
+}
+
+// Algo adds filter for algorithmic orders messages
+func (b *Builder) Algo() *Builder {
+	return b.add(Algo)
+}
+
+// Balance adds filter for balance info messages
+func (b *Builder) Balance() *Builder {
+	return b.add(Balance)
+}
+
+// Notify adds filter for notifications
+func (b *Builder) Notify() *Builder {
+	return b.add(Notify)
+}
+
+// Build returns the list of filters in the order they were added
+func (b *Builder) Build() []string {
+	res := make([]string, len(b.filters))
+	copy(res, b.filters)
+	return res
+}
+
+func (b *Builder) addScoped(prefix string, symbols ...string) *Builder {
+	if len(symbols) == 0 {
+		return b.add(prefix)
+	}
+
+	for _, s := range symbols {
+		b.add(join(prefix, s))
+	}
+	return b
+}
+
+func (b *Builder) add(f string) *Builder {
+	if b.seen[f] {
+		return b
+	}
+
+	b.seen[f] = true
+	b.filters = append(b.filters, f)
+	return b
+}
+
+func join(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if len(p) > 0 {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "-")
+}
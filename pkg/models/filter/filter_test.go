@@ -0,0 +1,51 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	cases := map[string]struct {
+		builder  *filter.Builder
+		expected []string
+	}{
+		"empty": {
+			builder:  filter.New(),
+			expected: []string{},
+		},
+		"unscoped filters": {
+			builder:  filter.New().Trading().Funding().Wallet().Algo().Balance().Notify(),
+			expected: []string{"trading", "funding", "wallet", "algo", "balance", "notify"},
+		},
+		"scoped filters": {
+			builder: filter.New().
+				Trading("tBTCUSD", "tETHUSD").
+				Funding("fUSD").
+				WalletCurrency("exchange", "USD"),
+			expected: []string{
+				"trading-tBTCUSD",
+				"trading-tETHUSD",
+				"funding-fUSD",
+				"wallet-exchange-USD",
+			},
+		},
+		"wallet type without currency": {
+			builder:  filter.New().WalletCurrency("margin", ""),
+			expected: []string{"wallet-margin"},
+		},
+		"duplicates are ignored": {
+			builder:  filter.New().Trading("tBTCUSD").Trading("tBTCUSD").Notify().Notify(),
+			expected: []string{"trading-tBTCUSD", "notify"},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := v.builder.Build()
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
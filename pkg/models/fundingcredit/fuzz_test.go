@@ -0,0 +1,77 @@
+//go:build go1.18
+// +build go1.18
+
+package fundingcredit_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingcredit.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingcredit.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingcredit.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzCancelFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingcredit.CancelFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]`,
+		`[[26222883,"fUST",1,1574013661000,1574079687000,350,null,"ACTIVE","FIXED",null,null,0.0024,2,1574013661000,1574078487000,0,null,null,0,null,0,"tBTCUST"]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingcredit.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
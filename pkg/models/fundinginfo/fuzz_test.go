@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package fundinginfo_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundinginfo"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["sym","fUSD",[0.0001,0.0002,2.5,3.1]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundinginfo.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
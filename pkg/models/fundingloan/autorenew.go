@@ -0,0 +1,64 @@
+package fundingloan
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// AutoRenew is funding auto-renew configuration of a currency
+type AutoRenew struct {
+	Currency string
+	Period   int64
+	// percentage rate, zero means FRR
+	Rate float64
+	// maximum amount to be auto-renewed, zero means whole balance
+	Threshold float64
+}
+
+// AutoRenewRequest turns funding auto-renew of the given currency on or off.
+// Zero amount, rate and period are left to api defaults
+type AutoRenewRequest struct {
+	Status   bool
+	Currency string
+	Amount   float64
+	Rate     float64
+	Period   int64
+}
+
+func (ar *AutoRenewRequest) ToJSON() ([]byte, error) {
+	aux := struct {
+		Status   int     `json:"status"`
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount,string,omitempty"`
+		Rate     float64 `json:"rate,string,omitempty"`
+		Period   int64   `json:"period,omitempty"`
+	}{
+		Currency: ar.Currency,
+		Amount:   ar.Amount,
+		Rate:     ar.Rate,
+		Period:   ar.Period,
+	}
+	if ar.Status {
+		aux.Status = 1
+	}
+	return json.Marshal(aux)
+}
+
+// AutoRenewFromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into an AutoRenew.
+func AutoRenewFromRaw(raw []interface{}) (*AutoRenew, error) {
+	if len(raw) < 4 {
+		return nil, fmt.Errorf("data slice too short for funding auto-renew: %#v", raw)
+	}
+
+	ar := &AutoRenew{
+		Currency:  convert.SValOrEmpty(raw[0]),
+		Period:    convert.I64ValOrZero(raw[1]),
+		Rate:      convert.F64ValOrZero(raw[2]),
+		Threshold: convert.F64ValOrZero(raw[3]),
+	}
+
+	return ar, nil
+}
@@ -0,0 +1,48 @@
+package fundingloan_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRenewRequest(t *testing.T) {
+	t.Run("ToJSON activate", func(t *testing.T) {
+		ar := fundingloan.AutoRenewRequest{Status: true, Currency: "USD", Amount: 500, Rate: 0.02, Period: 2}
+
+		got, err := ar.ToJSON()
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"status":1,"currency":"USD","amount":"500","rate":"0.02","period":2}`, string(got))
+	})
+
+	t.Run("ToJSON deactivate", func(t *testing.T) {
+		ar := fundingloan.AutoRenewRequest{Currency: "USD"}
+
+		got, err := ar.ToJSON()
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"status":0,"currency":"USD"}`, string(got))
+	})
+}
+
+func TestAutoRenewFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		ar, err := fundingloan.AutoRenewFromRaw([]interface{}{"USD"})
+		require.NotNil(t, err)
+		require.Nil(t, ar)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		ar, err := fundingloan.AutoRenewFromRaw([]interface{}{"USD", 2, 0.0002, 500})
+		require.Nil(t, err)
+
+		expected := &fundingloan.AutoRenew{
+			Currency:  "USD",
+			Period:    2,
+			Rate:      0.0002,
+			Threshold: 500,
+		}
+		assert.Equal(t, expected, ar)
+	})
+}
@@ -0,0 +1,90 @@
+//go:build go1.18
+// +build go1.18
+
+package fundingloan_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzCancelFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.CancelFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]`,
+		`[[2995368,"fUST",0,1574077517000,1574077517000,100,null,"ACTIVE","FIXED",null,null,0.0024,2,1574077517000,1574077517000,0,null,null,0,null,0]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzAutoRenewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["USD",2,0.0002,500]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingloan.AutoRenewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -27,3 +27,18 @@ func (cr *CancelRequest) MarshalJSON() ([]byte, error) {
 	}
 	return []byte(fmt.Sprintf("[0, \"foc\", null, %s]", string(b))), nil
 }
+
+// CancelAllRequest cancels all funding offers of the given currency, or of
+// all currencies when currency is empty
+type CancelAllRequest struct {
+	Currency string
+}
+
+func (cr *CancelAllRequest) ToJSON() ([]byte, error) {
+	resp := struct {
+		Currency string `json:"currency,omitempty"`
+	}{
+		Currency: cr.Currency,
+	}
+	return json.Marshal(resp)
+}
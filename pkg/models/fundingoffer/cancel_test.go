@@ -19,3 +19,15 @@ func TestCancelRequest(t *testing.T) {
 		assert.Equal(t, expected, string(got))
 	})
 }
+
+func TestCancelAllRequest(t *testing.T) {
+	t.Run("ToJSON", func(t *testing.T) {
+		got, err := (&fundingoffer.CancelAllRequest{Currency: "USD"}).ToJSON()
+		require.Nil(t, err)
+		assert.Equal(t, `{"currency":"USD"}`, string(got))
+
+		got, err = (&fundingoffer.CancelAllRequest{}).ToJSON()
+		require.Nil(t, err)
+		assert.Equal(t, `{}`, string(got))
+	})
+}
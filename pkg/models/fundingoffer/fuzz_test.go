@@ -0,0 +1,77 @@
+//go:build go1.18
+// +build go1.18
+
+package fundingoffer_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingoffer.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingoffer.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingoffer.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzCancelFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingoffer.CancelFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]`,
+		`[[652606505,"fETH",1574000611000,1574000611000,0.29797676,0.29797676,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingoffer.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
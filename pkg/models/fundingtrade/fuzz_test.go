@@ -0,0 +1,52 @@
+//go:build go1.18
+// +build go1.18
+
+package fundingtrade_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingtrade"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingtrade.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]`,
+		`[[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingtrade.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzHistoricalSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]`,
+		`[[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = fundingtrade.HistoricalSnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
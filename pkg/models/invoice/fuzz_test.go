@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package invoice_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/invoice"
+)
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["invoicehash","invoice",null,null,"0.002"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = invoice.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -0,0 +1,38 @@
+//go:build go1.18
+// +build go1.18
+
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ledger"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2531822314,"USD",null,1573521810000,null,0.01644445,0,null,"Settlement @ 185.79 on wallet margin"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = ledger.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[2531822314,"USD",null,1573521810000,null,0.01644445,0,null,"Settlement @ 185.79 on wallet margin"]`,
+		`[[2531822314,"USD",null,1573521810000,null,0.01644445,0,null,"Settlement @ 185.79 on wallet margin"]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = ledger.SnapshotFromRaw(fuzzutil.Decode(t, data), ledger.FromRaw)
+	})
+}
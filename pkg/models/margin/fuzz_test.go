@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package margin_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/margin"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["base",[-13.014640000000007,0,49331.70267297,49318.68803297,27]]`,
+		`["sym","tETHUSD",[149361.09689202666,149639.26293509,830.0182168075556,895.0658432466332]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = margin.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[["base",[-13.014640000000007,0,49331.70267297,49318.68803297,27]]]`,
+		`[["sym","tETHUSD",[149361.09689202666,149639.26293509,830.0182168075556,895.0658432466332]]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = margin.UpdateSnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
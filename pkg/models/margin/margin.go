@@ -22,6 +22,10 @@ type InfoUpdate struct {
 	Sell            float64
 }
 
+type UpdateSnapshot struct {
+	Snapshot []*InfoUpdate
+}
+
 // FromRaw returns either a InfoBase or InfoUpdate, since
 // the Margin Info is split up into a base and per symbol parts.
 func FromRaw(raw []interface{}) (o interface{}, err error) {
@@ -60,6 +64,56 @@ func FromRaw(raw []interface{}) (o interface{}, err error) {
 	return nil, fmt.Errorf("invalid margin info type in %#v", raw)
 }
 
+// BaseFromRaw takes ["base", [...]] payload, as sent by both websocket and
+// rest services, and tries to convert it into an InfoBase.
+func BaseFromRaw(raw []interface{}) (*InfoBase, error) {
+	o, err := FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	ib, ok := o.(*InfoBase)
+	if !ok {
+		return nil, fmt.Errorf("expected margin info base but got %#v", raw)
+	}
+	return ib, nil
+}
+
+// UpdateFromRaw takes ["sym", SYMBOL, [...]] payload, as sent by both websocket
+// and rest services, and tries to convert it into an InfoUpdate.
+func UpdateFromRaw(raw []interface{}) (*InfoUpdate, error) {
+	o, err := FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	iu, ok := o.(*InfoUpdate)
+	if !ok {
+		return nil, fmt.Errorf("expected margin info update but got %#v", raw)
+	}
+	return iu, nil
+}
+
+// UpdateSnapshotFromRaw takes a list of per symbol margin info payloads and
+// tries to convert it into an UpdateSnapshot.
+func UpdateSnapshotFromRaw(raw []interface{}) (*UpdateSnapshot, error) {
+	ius := make([]*InfoUpdate, 0, len(raw))
+	for _, v := range raw {
+		r, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected margin info update slice but got %#v", v)
+		}
+
+		iu, err := UpdateFromRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		ius = append(ius, iu)
+	}
+
+	return &UpdateSnapshot{Snapshot: ius}, nil
+}
+
 func updateFromRaw(symbol string, raw []interface{}) (o *InfoUpdate, err error) {
 	if len(raw) < 4 {
 		return o, fmt.Errorf("data slice too short for margin info update: %#v", raw)
@@ -93,3 +93,57 @@ func TestNewMarginFromRaw(t *testing.T) {
 		assert.Equal(t, expected, got)
 	})
 }
+
+func TestBaseFromRaw(t *testing.T) {
+	t.Run("symbol payload", func(t *testing.T) {
+		payload := []interface{}{"sym", "tETHUSD", []interface{}{149361.09689202, 149639.26293509, 1, 1}}
+
+		got, err := margin.BaseFromRaw(payload)
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("valid payload", func(t *testing.T) {
+		payload := []interface{}{"base", []interface{}{-13.014640000000007, 0, 49331.70267297, 49318.68803297, 27}}
+
+		got, err := margin.BaseFromRaw(payload)
+		require.Nil(t, err)
+		assert.Equal(t, 49331.70267297, got.MarginBalance)
+	})
+}
+
+func TestUpdateSnapshotFromRaw(t *testing.T) {
+	t.Run("base payload", func(t *testing.T) {
+		payload := []interface{}{
+			[]interface{}{"base", []interface{}{-13.014640000000007, 0, 49331.70267297, 49318.68803297, 27}},
+		}
+
+		got, err := margin.UpdateSnapshotFromRaw(payload)
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		got, err := margin.UpdateSnapshotFromRaw([]interface{}{"sym"})
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("valid payload", func(t *testing.T) {
+		payload := []interface{}{
+			[]interface{}{"sym", "tETHUSD", []interface{}{149361.09689202, 149639.26293509, 1, 1}},
+			[]interface{}{"sym", "tBTCUSD", []interface{}{12.5, 13.5, 0.5, 0.25}},
+		}
+
+		got, err := margin.UpdateSnapshotFromRaw(payload)
+		require.Nil(t, err)
+
+		expected := &margin.UpdateSnapshot{
+			Snapshot: []*margin.InfoUpdate{
+				{Symbol: "tETHUSD", TradableBalance: 149361.09689202, GrossBalance: 149639.26293509, Buy: 1, Sell: 1},
+				{Symbol: "tBTCUSD", TradableBalance: 12.5, GrossBalance: 13.5, Buy: 0.5, Sell: 0.25},
+			},
+		}
+		assert.Equal(t, expected, got)
+	})
+}
@@ -0,0 +1,46 @@
+//go:build go1.18
+// +build go1.18
+
+package movement_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/movement"
+)
+
+var seeds = []string{
+	`[]`,
+	`[null]`,
+	`[13105603,"ETH","ETHEREUM",null,null,1569348774000,1569348774000,null,null,"COMPLETED",null,null,-0.26300954,-0.00135,null,null,"0x5e2b",null,null,null,"0x5a6f",null]`,
+	`[13105603,"EUR","EURO",null,"wire",1569348774000,1569348774000,null,null,"PENDING",null,null,-100,-1,null,null,null,"ref",null,null,null,null,25,2,null,"ext-1","SETTLED","wire",null]`,
+}
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = movement.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzInfoFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = movement.InfoFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+		f.Add("[" + seed + "]")
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = movement.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -0,0 +1,129 @@
+package movement
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// Status provides a typed set of deposit and withdrawal statuses
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusCompleted Status = "COMPLETED"
+	StatusCanceled  Status = "CANCELED"
+)
+
+// Final reports whether movement with this status will not change anymore
+func (s Status) Final() bool {
+	return s == StatusCompleted || s == StatusCanceled
+}
+
+// Movement is a deposit or a withdrawal. Withdrawals have negative amount
+type Movement struct {
+	ID           int64
+	Currency     string
+	CurrencyName string
+	MTSStarted   int64
+	MTSUpdated   int64
+	Status       Status
+	Amount       float64
+	Fees         float64
+	// address funds were sent to
+	DestinationAddress string
+	// blockchain transaction id
+	TransactionID           string
+	WithdrawTransactionNote string
+}
+
+// Info is a detailed movement, as returned by movement info lookup
+type Info struct {
+	Movement
+	Remark    string
+	PaymentID string
+	// bank details, only present for fiat movements
+	BankFees                   float64
+	BankRouterID               int64
+	ExternalBankMovID          string
+	ExternalBankMovStatus      string
+	ExternalBankMovDescription string
+}
+
+type Snapshot struct {
+	Snapshot []*Movement
+}
+
+// Withdrawal reports whether movement is a withdrawal
+func (m *Movement) Withdrawal() bool {
+	return m.Amount < 0
+}
+
+// FromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into a Movement.
+func FromRaw(raw []interface{}) (*Movement, error) {
+	if len(raw) < 22 {
+		return nil, fmt.Errorf("data slice too short for movement: %#v", raw)
+	}
+
+	m := &Movement{
+		ID:                      convert.I64ValOrZero(raw[0]),
+		Currency:                convert.SValOrEmpty(raw[1]),
+		CurrencyName:            convert.SValOrEmpty(raw[2]),
+		MTSStarted:              convert.I64ValOrZero(raw[5]),
+		MTSUpdated:              convert.I64ValOrZero(raw[6]),
+		Status:                  Status(convert.SValOrEmpty(raw[9])),
+		Amount:                  convert.F64ValOrZero(raw[12]),
+		Fees:                    convert.F64ValOrZero(raw[13]),
+		DestinationAddress:      convert.SValOrEmpty(raw[16]),
+		TransactionID:           convert.SValOrEmpty(raw[20]),
+		WithdrawTransactionNote: convert.SValOrEmpty(raw[21]),
+	}
+
+	return m, nil
+}
+
+// InfoFromRaw takes the raw list of values as returned from the movement
+// info endpoint and tries to convert it into an Info.
+func InfoFromRaw(raw []interface{}) (*Info, error) {
+	m, err := FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Info{
+		Movement:  *m,
+		Remark:    convert.SValOrEmpty(raw[4]),
+		PaymentID: convert.SValOrEmpty(raw[17]),
+	}
+
+	if len(raw) > 27 {
+		i.BankFees = convert.F64ValOrZero(raw[22])
+		i.BankRouterID = convert.I64ValOrZero(raw[23])
+		i.ExternalBankMovID = convert.SValOrEmpty(raw[25])
+		i.ExternalBankMovStatus = convert.SValOrEmpty(raw[26])
+		i.ExternalBankMovDescription = convert.SValOrEmpty(raw[27])
+	}
+
+	return i, nil
+}
+
+// SnapshotFromRaw takes a raw list of values as returned from the rest
+// service and tries to convert it into a Snapshot.
+func SnapshotFromRaw(raw []interface{}) (*Snapshot, error) {
+	ms := make([]*Movement, 0, len(raw))
+	for _, v := range raw {
+		r, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected movement slice, got: %#v", v)
+		}
+
+		m, err := FromRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+
+	return &Snapshot{Snapshot: ms}, nil
+}
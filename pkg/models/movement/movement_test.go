@@ -0,0 +1,112 @@
+package movement_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/movement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawMovement() []interface{} {
+	return []interface{}{
+		13105603, "ETH", "ETHEREUM", nil, nil, 1569348774000, 1569348774000, nil, nil,
+		"COMPLETED", nil, nil, -0.26300954, -0.00135, nil, nil,
+		"0x5e2bd54e3e4e4f8c5b2e1f29e8a8ef4a3a45d3f4", nil, nil, nil,
+		"0x5a6f8e9d3b1e56b5b4d0f8d1c0b1b7a3b1b2c3d4", "Sent from treasury",
+	}
+}
+
+func TestFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		m, err := movement.FromRaw([]interface{}{13105603})
+		require.NotNil(t, err)
+		require.Nil(t, m)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		m, err := movement.FromRaw(rawMovement())
+		require.Nil(t, err)
+
+		expected := &movement.Movement{
+			ID:                      13105603,
+			Currency:                "ETH",
+			CurrencyName:            "ETHEREUM",
+			MTSStarted:              1569348774000,
+			MTSUpdated:              1569348774000,
+			Status:                  movement.StatusCompleted,
+			Amount:                  -0.26300954,
+			Fees:                    -0.00135,
+			DestinationAddress:      "0x5e2bd54e3e4e4f8c5b2e1f29e8a8ef4a3a45d3f4",
+			TransactionID:           "0x5a6f8e9d3b1e56b5b4d0f8d1c0b1b7a3b1b2c3d4",
+			WithdrawTransactionNote: "Sent from treasury",
+		}
+
+		assert.Equal(t, expected, m)
+		assert.True(t, m.Withdrawal())
+		assert.True(t, m.Status.Final())
+	})
+}
+
+func TestInfoFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		i, err := movement.InfoFromRaw([]interface{}{13105603})
+		require.NotNil(t, err)
+		require.Nil(t, i)
+	})
+
+	t.Run("crypto movement", func(t *testing.T) {
+		raw := rawMovement()
+		raw[4], raw[17] = "withdrawal", "memo-1"
+
+		i, err := movement.InfoFromRaw(raw)
+		require.Nil(t, err)
+		assert.Equal(t, int64(13105603), i.ID)
+		assert.Equal(t, "withdrawal", i.Remark)
+		assert.Equal(t, "memo-1", i.PaymentID)
+		assert.Equal(t, "", i.ExternalBankMovID)
+	})
+
+	t.Run("bank movement", func(t *testing.T) {
+		raw := append(rawMovement(), 25.0, 2, nil, "ext-1", "SETTLED", "wire", nil)
+
+		i, err := movement.InfoFromRaw(raw)
+		require.Nil(t, err)
+		assert.Equal(t, 25.0, i.BankFees)
+		assert.Equal(t, int64(2), i.BankRouterID)
+		assert.Equal(t, "ext-1", i.ExternalBankMovID)
+		assert.Equal(t, "SETTLED", i.ExternalBankMovStatus)
+		assert.Equal(t, "wire", i.ExternalBankMovDescription)
+	})
+}
+
+func TestSnapshotFromRaw(t *testing.T) {
+	t.Run("no movements", func(t *testing.T) {
+		s, err := movement.SnapshotFromRaw([]interface{}{})
+		require.Nil(t, err)
+		assert.Empty(t, s.Snapshot)
+	})
+
+	t.Run("invalid movement", func(t *testing.T) {
+		s, err := movement.SnapshotFromRaw([]interface{}{rawMovement(), []interface{}{1}})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+
+		s, err = movement.SnapshotFromRaw([]interface{}{"ETH"})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("valid movements", func(t *testing.T) {
+		deposit := rawMovement()
+		deposit[0], deposit[9], deposit[12] = 13105604, "PENDING", 1.5
+
+		s, err := movement.SnapshotFromRaw([]interface{}{rawMovement(), deposit})
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+		assert.Equal(t, int64(13105604), s.Snapshot[1].ID)
+		assert.Equal(t, movement.StatusPending, s.Snapshot[1].Status)
+		assert.False(t, s.Snapshot[1].Withdrawal())
+		assert.False(t, s.Snapshot[1].Status.Final())
+	})
+}
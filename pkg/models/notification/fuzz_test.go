@@ -0,0 +1,26 @@
+//go:build go1.18
+// +build go1.18
+
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[1575289447641,"on-req",null,null,[[33950998275,null,1573476747887,"tETHUSD",1573476748000,1573476748000,-0.5,-0.5,"LIMIT",null,null,null,0,"ACTIVE",null,null,220,0,0,0,null,null,null,0,0,null,null,null,"BFX",null,null,null]],null,"SUCCESS","Submitting limit buy order for 0.2 BTC."]`,
+		`[1575289447641,"on-req",null,null,[[33950998275,null,1573476747887,"tETHUSD",1573476748000,1573476748000,-0.5,-0.5,"LIMIT",null,null,null,0,"ACTIVE",null,null,220,0,0,0,null,null,null,0,0,null,null,null,"BFX",null,null,null]],null,"SUCCESS","Submitting limit buy order for 0.2 BTC."]`,
+		`[0,"pu",null,null,["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}],null,"SUCCESS",""]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = notification.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
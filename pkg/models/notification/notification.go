@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/position"
@@ -38,7 +39,10 @@ func FromRaw(raw []interface{}) (n *Notification, err error) {
 		return
 	}
 
-	nraw := raw[4].([]interface{})
+	nraw, ok := raw[4].([]interface{})
+	if !ok {
+		return n, fmt.Errorf("unexpected notify info for notification: %#v", raw[4])
+	}
 	if len(nraw) == 0 {
 		return
 	}
@@ -66,6 +70,9 @@ func FromRaw(raw []interface{}) (n *Notification, err error) {
 	case "foc-req":
 		n.NotifyInfo, err = fundingoffer.CancelFromRaw(nraw)
 		return
+	case "fa-req":
+		n.NotifyInfo, err = fundingloan.AutoRenewFromRaw(nraw)
+		return
 	case "pm-req", "pc":
 		n.NotifyInfo, err = position.CancelFromRaw(nraw)
 		return
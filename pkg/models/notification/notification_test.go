@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
@@ -214,6 +215,32 @@ func TestNotificationMapping(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		"fa-req": {
+			pld: []byte(`[
+				0,
+				"n",
+				[
+					1611922089,"fa-req",null,null,
+					["USD",2,0.0002,500],
+					null,"SUCCESS","auto-renew activated"
+				]
+			]`),
+			expected: &notification.Notification{
+				MTS:  1611922089,
+				Type: "fa-req",
+				NotifyInfo: &fundingloan.AutoRenew{
+					Currency:  "USD",
+					Period:    2,
+					Rate:      0.0002,
+					Threshold: 500,
+				},
+				Status: "SUCCESS",
+				Text:   "auto-renew activated",
+			},
+			err: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
 	}
 
 	for k, v := range cases {
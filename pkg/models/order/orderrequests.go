@@ -204,3 +204,51 @@ func (cr *CancelRequest) MarshalJSON() ([]byte, error) {
 	}
 	return []byte(fmt.Sprintf("[0, \"oc\", null, %s]", string(b))), nil
 }
+
+// ClientID identifies an order by client id and the date it was set on,
+// in 2006-01-02 format
+type ClientID struct {
+	CID  int64
+	Date string
+}
+
+// CancelMultiRequest represents request cancelling several orders at once,
+// by internal ids, group ids or client ids. All cancels all open orders
+type CancelMultiRequest struct {
+	ID  []int64
+	GID []int64
+	CID []ClientID
+	All bool
+}
+
+func (cr *CancelMultiRequest) ToJSON() ([]byte, error) {
+	resp := struct {
+		ID  []int64         `json:"id,omitempty"`
+		GID []int64         `json:"gid,omitempty"`
+		CID [][]interface{} `json:"cid,omitempty"`
+		All int             `json:"all,omitempty"`
+	}{
+		ID:  cr.ID,
+		GID: cr.GID,
+	}
+
+	for _, c := range cr.CID {
+		resp.CID = append(resp.CID, []interface{}{c.CID, c.Date})
+	}
+
+	if cr.All {
+		resp.All = 1
+	}
+
+	return json.Marshal(resp)
+}
+
+// MarshalJSON converts the order cancel multi object into the format required
+// by the bitfinex websocket service.
+func (cr *CancelMultiRequest) MarshalJSON() ([]byte, error) {
+	b, err := cr.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("[0, \"oc_multi\", null, %s]", string(b))), nil
+}
@@ -131,3 +131,29 @@ func TestOrderCancelRequest(t *testing.T) {
 		assert.Equal(t, expected, string(got))
 	})
 }
+
+func TestOrderCancelMultiRequest(t *testing.T) {
+	t.Run("MarshalJSON", func(t *testing.T) {
+		ocr := order.CancelMultiRequest{
+			ID:  []int64{1, 2},
+			GID: []int64{3},
+			CID: []order.ClientID{{CID: 4, Date: "2020-10-10"}},
+		}
+		got, err := ocr.MarshalJSON()
+
+		require.Nil(t, err)
+
+		expected := "[0, \"oc_multi\", null, {\"id\":[1,2],\"gid\":[3],\"cid\":[[4,\"2020-10-10\"]]}]"
+		assert.Equal(t, expected, string(got))
+	})
+
+	t.Run("MarshalJSON all", func(t *testing.T) {
+		ocr := order.CancelMultiRequest{All: true}
+		got, err := ocr.MarshalJSON()
+
+		require.Nil(t, err)
+
+		expected := "[0, \"oc_multi\", null, {\"all\":1}]"
+		assert.Equal(t, expected, string(got))
+	})
+}
@@ -0,0 +1,132 @@
+//go:build go1.18
+// +build go1.18
+
+package position_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/position"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzCancelFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.CancelFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]`,
+		`[["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,-0.334,-0.0003,7045.87,3.067,null,142355652,1574002216000,1574002216000,null,0,null,0,0,{"reason":"TRADE","order_id":34271018124}]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzHistoryFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD","CLOSED",0,7290.1,-0.00021,0,null,null,null,null,null,142355652,1574002216000,1574002346000]`,
+		`["tBTCUSD","ACTIVE",0.0195,8565.0267019,0,0,null,null,null,null,null,142355652,1574002216000,1574002216000,null,1,null,16.5,8.25,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.HistoryFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzHistorySnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[["tBTCUSD","CLOSED",0,7290.1,-0.00021,0,null,null,null,null,null,142355652,1574002216000,1574002346000]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.HistorySnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzIncreaseFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`["tBTCUSD",null,null,null,0.0001,34000.5]`,
+		`[["tBTCUSD",null,null,null,0.0001,34000.5]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.IncreaseFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzIncreaseInfoFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[1000,0,0.5,120.5,240.75,0.1,0.2],[350.25],null,null,[34.1,3.41],["USD","BTC"]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = position.IncreaseInfoFromRaw(fuzzutil.Decode(t, data))
+	})
+}
@@ -0,0 +1,100 @@
+package position
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// IncreaseInfo describes how much a position of the given symbol can be
+// increased by, and funding it would take
+type IncreaseInfo struct {
+	MaxPos                       float64
+	CurrentPos                   float64
+	BaseCurrencyBalance          float64
+	TradableBalanceQuoteCurrency float64
+	TradableBalanceQuoteTotal    float64
+	TradableBalanceBaseCurrency  float64
+	TradableBalanceBaseTotal     float64
+	FundingAvail                 float64
+	FundingValue                 float64
+	FundingRequired              float64
+	FundingValueCurrency         string
+	FundingRequiredCurrency      string
+}
+
+type IncreaseRequest struct {
+	Symbol string
+	Amount float64
+}
+
+func (o *IncreaseRequest) ToJSON() ([]byte, error) {
+	aux := struct {
+		Symbol string `json:"symbol"`
+		Amount string `json:"amount"`
+	}{
+		Symbol: o.Symbol,
+		Amount: strconv.FormatFloat(o.Amount, 'f', -1, 64),
+	}
+	return json.Marshal(aux)
+}
+
+// IncreaseFromRaw takes notify info of position increase notification and
+// tries to convert it into a Position. Only symbol, amount and base price
+// are sent, info may come wrapped in a single element list
+func IncreaseFromRaw(raw []interface{}) (*Position, error) {
+	if len(raw) == 1 {
+		if inner, ok := raw[0].([]interface{}); ok {
+			raw = inner
+		}
+	}
+
+	if len(raw) < 6 {
+		return nil, fmt.Errorf("data slice too short for position increase: %#v", raw)
+	}
+
+	p := &Position{
+		Symbol:    convert.SValOrEmpty(raw[0]),
+		Amount:    convert.F64ValOrZero(raw[4]),
+		BasePrice: convert.F64ValOrZero(raw[5]),
+	}
+
+	return p, nil
+}
+
+// IncreaseInfoFromRaw takes the raw list of values as returned from the
+// position increase info endpoint and tries to convert it into an IncreaseInfo.
+// Response is made of nested lists: balances, funding available, two
+// placeholders, funding value and required, and their currencies
+func IncreaseInfoFromRaw(raw []interface{}) (*IncreaseInfo, error) {
+	if len(raw) < 6 {
+		return nil, fmt.Errorf("data slice too short for position increase info: %#v", raw)
+	}
+
+	balances, _ := raw[0].([]interface{})
+	avail, _ := raw[1].([]interface{})
+	funding, _ := raw[4].([]interface{})
+	currencies, _ := raw[5].([]interface{})
+	if len(balances) < 7 || len(avail) < 1 || len(funding) < 2 || len(currencies) < 2 {
+		return nil, fmt.Errorf("unexpected data for position increase info: %#v", raw)
+	}
+
+	ii := &IncreaseInfo{
+		MaxPos:                       convert.F64ValOrZero(balances[0]),
+		CurrentPos:                   convert.F64ValOrZero(balances[1]),
+		BaseCurrencyBalance:          convert.F64ValOrZero(balances[2]),
+		TradableBalanceQuoteCurrency: convert.F64ValOrZero(balances[3]),
+		TradableBalanceQuoteTotal:    convert.F64ValOrZero(balances[4]),
+		TradableBalanceBaseCurrency:  convert.F64ValOrZero(balances[5]),
+		TradableBalanceBaseTotal:     convert.F64ValOrZero(balances[6]),
+		FundingAvail:                 convert.F64ValOrZero(avail[0]),
+		FundingValue:                 convert.F64ValOrZero(funding[0]),
+		FundingRequired:              convert.F64ValOrZero(funding[1]),
+		FundingValueCurrency:         convert.SValOrEmpty(currencies[0]),
+		FundingRequiredCurrency:      convert.SValOrEmpty(currencies[1]),
+	}
+
+	return ii, nil
+}
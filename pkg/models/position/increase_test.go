@@ -0,0 +1,113 @@
+package position_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/position"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncreaseRequestToJSON(t *testing.T) {
+	ir := &position.IncreaseRequest{Symbol: "tBTCUSD", Amount: 0.0001}
+	got, err := ir.ToJSON()
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"symbol":"tBTCUSD","amount":"0.0001"}`, string(got))
+}
+
+func TestIncreaseFromRaw(t *testing.T) {
+	cases := map[string]struct {
+		pld      []interface{}
+		expected *position.Position
+		err      func(*testing.T, error)
+	}{
+		"invalid pld": {
+			pld:      []interface{}{"tBTCUSD"},
+			expected: nil,
+			err: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		"plain info": {
+			pld:      []interface{}{"tBTCUSD", nil, nil, nil, 0.0001, 34000.5},
+			expected: &position.Position{Symbol: "tBTCUSD", Amount: 0.0001, BasePrice: 34000.5},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		"wrapped info": {
+			pld:      []interface{}{[]interface{}{"tBTCUSD", nil, nil, nil, 0.0001, 34000.5}},
+			expected: &position.Position{Symbol: "tBTCUSD", Amount: 0.0001, BasePrice: 34000.5},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := position.IncreaseFromRaw(v.pld)
+			v.err(t, err)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
+
+func TestIncreaseInfoFromRaw(t *testing.T) {
+	cases := map[string]struct {
+		pld      []interface{}
+		expected *position.IncreaseInfo
+		err      func(*testing.T, error)
+	}{
+		"invalid pld": {
+			pld:      []interface{}{[]interface{}{1000, 0}},
+			expected: nil,
+			err: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		"missing funding details": {
+			pld: []interface{}{
+				[]interface{}{1000, 0, 0.5, 120.5, 240.75, 0.1, 0.2},
+				[]interface{}{350.25}, nil, nil, nil, nil,
+			},
+			expected: nil,
+			err: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		"valid pld": {
+			pld: []interface{}{
+				[]interface{}{1000, 0, 0.5, 120.5, 240.75, 0.1, 0.2},
+				[]interface{}{350.25}, nil, nil,
+				[]interface{}{34.1, 3.41},
+				[]interface{}{"USD", "BTC"},
+			},
+			expected: &position.IncreaseInfo{
+				MaxPos:                       1000,
+				CurrentPos:                   0,
+				BaseCurrencyBalance:          0.5,
+				TradableBalanceQuoteCurrency: 120.5,
+				TradableBalanceQuoteTotal:    240.75,
+				TradableBalanceBaseCurrency:  0.1,
+				TradableBalanceBaseTotal:     0.2,
+				FundingAvail:                 350.25,
+				FundingValue:                 34.1,
+				FundingRequired:              3.41,
+				FundingValueCurrency:         "USD",
+				FundingRequiredCurrency:      "BTC",
+			},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := position.IncreaseInfoFromRaw(v.pld)
+			v.err(t, err)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
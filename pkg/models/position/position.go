@@ -119,6 +119,55 @@ func SnapshotFromRaw(raw []interface{}) (s *Snapshot, err error) {
 	return
 }
 
+// HistoryFromRaw takes the raw list of values as returned from positions
+// history, snapshot and audit endpoints and tries to convert it into a
+// Position. History entries leave out live fields like profit and loss, full
+// entries are converted same as FromRaw does.
+func HistoryFromRaw(raw []interface{}) (*Position, error) {
+	if len(raw) >= 20 {
+		return FromRaw(raw)
+	}
+
+	if len(raw) < 14 {
+		return nil, fmt.Errorf("data slice too short for position history: %#v", raw)
+	}
+
+	p := &Position{
+		Symbol:            convert.SValOrEmpty(raw[0]),
+		Status:            convert.SValOrEmpty(raw[1]),
+		Amount:            convert.F64ValOrZero(raw[2]),
+		BasePrice:         convert.F64ValOrZero(raw[3]),
+		MarginFunding:     convert.F64ValOrZero(raw[4]),
+		MarginFundingType: convert.I64ValOrZero(raw[5]),
+		Id:                convert.I64ValOrZero(raw[11]),
+		MtsCreate:         convert.I64ValOrZero(raw[12]),
+		MtsUpdate:         convert.I64ValOrZero(raw[13]),
+	}
+
+	return p, nil
+}
+
+// HistorySnapshotFromRaw takes a raw list of values as returned from
+// positions history, snapshot and audit endpoints and tries to convert it
+// into a Snapshot. Empty history is not an error.
+func HistorySnapshotFromRaw(raw []interface{}) (*Snapshot, error) {
+	ps := make([]*Position, 0, len(raw))
+	for _, v := range raw {
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected position slice, got: %#v", v)
+		}
+
+		p, err := HistoryFromRaw(l)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return &Snapshot{Snapshot: ps}, nil
+}
+
 type ClaimRequest struct {
 	Id int64
 }
@@ -408,3 +408,122 @@ func TestCancelFromRaw(t *testing.T) {
 	assert.Equal(t, expected, got)
 	assert.Equal(t, "pc", p.Type)
 }
+
+func TestHistoryFromRaw(t *testing.T) {
+	cases := map[string]struct {
+		pld      []interface{}
+		expected *position.Position
+		err      func(*testing.T, error)
+	}{
+		"invalid pld": {
+			pld:      []interface{}{"tBTCUSD", "CLOSED", 0, 7290.1},
+			expected: nil,
+			err: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		"rest positions history": {
+			pld: []interface{}{
+				"tBTCUSD", "CLOSED", 0, 7290.1, -0.00021, 0, nil, nil, nil, nil, nil,
+				142355652, 1574002216000, 1574002346000,
+			},
+			expected: &position.Position{
+				Id:            142355652,
+				Symbol:        "tBTCUSD",
+				Status:        "CLOSED",
+				BasePrice:     7290.1,
+				MarginFunding: -0.00021,
+				MtsCreate:     1574002216000,
+				MtsUpdate:     1574002346000,
+			},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		"rest positions audit": {
+			pld: []interface{}{
+				"tBTCUSD", "ACTIVE", 0.0195, 8565.0267019, -0.00021, 1, nil, nil, nil, nil, nil,
+				142355652, 1574002216000, 1574002216000, nil, 1, nil, 16.5, 8.25, nil,
+			},
+			expected: &position.Position{
+				Id:                142355652,
+				Symbol:            "tBTCUSD",
+				Status:            "ACTIVE",
+				Amount:            0.0195,
+				BasePrice:         8565.0267019,
+				MarginFunding:     -0.00021,
+				MarginFundingType: 1,
+				MtsCreate:         1574002216000,
+				MtsUpdate:         1574002216000,
+				Collateral:        16.5,
+				CollateralMin:     8.25,
+			},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := position.HistoryFromRaw(v.pld)
+			v.err(t, err)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
+
+func TestHistorySnapshotFromRaw(t *testing.T) {
+	cases := map[string]struct {
+		pld      []interface{}
+		expected *position.Snapshot
+		err      func(*testing.T, error)
+	}{
+		"empty history": {
+			pld:      []interface{}{},
+			expected: &position.Snapshot{Snapshot: []*position.Position{}},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		"invalid pld": {
+			pld:      []interface{}{"tBTCUSD"},
+			expected: nil,
+			err: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		"rest positions history": {
+			pld: []interface{}{
+				[]interface{}{
+					"tBTCUSD", "CLOSED", 0, 7290.1, -0.00021, 0, nil, nil, nil, nil, nil,
+					142355652, 1574002216000, 1574002346000,
+				},
+			},
+			expected: &position.Snapshot{
+				Snapshot: []*position.Position{
+					{
+						Id:            142355652,
+						Symbol:        "tBTCUSD",
+						Status:        "CLOSED",
+						BasePrice:     7290.1,
+						MarginFunding: -0.00021,
+						MtsCreate:     1574002216000,
+						MtsUpdate:     1574002346000,
+					},
+				},
+			},
+			err: func(t *testing.T, err error) {
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := position.HistorySnapshotFromRaw(v.pld)
+			v.err(t, err)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
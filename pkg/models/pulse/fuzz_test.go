@@ -0,0 +1,38 @@
+//go:build go1.18
+// +build go1.18
+
+package pulse_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/pulse"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["pulse-id",1591614631576,null,"user-id",null,"title","content",null,null,1,1,0,["tag"],["attachment"],[],9,null,null,[["abc123",1591614631576,null,"nickname",null,"picture","text",null,null,"handle",null,12,15,null,null,null,1]],0,null,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = pulse.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["pulse-id",1591614631576,null,"user-id",null,"title","content",null,null,1,1,0,["tag"],["attachment"],[],9,null,null,[["abc123",1591614631576,null,"nickname",null,"picture","text",null,null,"handle",null,12,15,null,null,null,1]],0,null,null]`,
+		`[["pulse-id",1591614631576,null,"user-id",null,"title","content",null,null,1,1,0,["tag"],["attachment"],[],9,null,null,[["abc123",1591614631576,null,"nickname",null,"picture","text",null,null,"handle",null,12,15,null,null,null,1]],0,null,null]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = pulse.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
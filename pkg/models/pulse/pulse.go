@@ -52,7 +52,7 @@ var pulseFields = map[string]int{
 
 // FromRaw returns pointer to Pulse message
 func FromRaw(raw []interface{}) (*Pulse, error) {
-	if len(raw) < 19 {
+	if len(raw) < 20 {
 		return nil, fmt.Errorf("data slice too short for Pulse Message: %#v", raw)
 	}
 
@@ -107,8 +107,12 @@ func SnapshotFromRaw(raws []interface{}) ([]*Pulse, error) {
 
 	res := []*Pulse{}
 
-	for _, raw := range raws {
-		raw := raw.([]interface{})
+	for _, r := range raws {
+		raw, ok := r.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected Pulse Message slice, got: %#v", r)
+		}
+
 		p, err := FromRaw(raw)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package pulseprofile_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/pulseprofile"
+)
+
+func FuzzNewFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["abc123",1591614631576,null,"nickname",null,"picture","text",null,null,"handle",null,12,15,null,null,null,1]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = pulseprofile.NewFromRaw(fuzzutil.Decode(t, data))
+	})
+}
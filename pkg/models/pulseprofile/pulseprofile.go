@@ -42,7 +42,7 @@ var pulseProfileFields = map[string]int{
 // NewFromRaw takes in slice of interfaces and converts them to
 // pointer to Pulse Profile
 func NewFromRaw(raw []interface{}) (*PulseProfile, error) {
-	if len(raw) < 14 {
+	if len(raw) < 17 {
 		return nil, fmt.Errorf("data slice too short for PulseProfile: %#v", raw)
 	}
 
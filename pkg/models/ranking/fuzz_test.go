@@ -0,0 +1,37 @@
+//go:build go1.18
+// +build go1.18
+
+package ranking_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ranking"
+)
+
+var seeds = []string{
+	`[]`,
+	`[null]`,
+	`[1609144352338,null,"trader",1,null,null,12345.67]`,
+	`[1609144352338,null,"trader",1,null,null,12345.67,null,null,"trader_twitter"]`,
+}
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = ranking.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+		f.Add("[" + seed + "]")
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = ranking.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
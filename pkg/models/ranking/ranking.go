@@ -0,0 +1,80 @@
+package ranking
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// Key provides a typed set of leaderboard keys
+type Key string
+
+const (
+	KeyUnrealizedProfitDiff Key = "plu_diff"
+	KeyUnrealizedProfit     Key = "plu"
+	KeyVolume               Key = "vol"
+	KeyRealizedProfit       Key = "plr"
+)
+
+// TimeFrame provides a typed set of leaderboard time frames
+type TimeFrame string
+
+const (
+	ThreeHours TimeFrame = "3h"
+	OneWeek    TimeFrame = "1w"
+	OneMonth   TimeFrame = "1M"
+)
+
+// Ranking is a single leaderboard entry
+type Ranking struct {
+	MTS           int64
+	Username      string
+	Ranking       int64
+	Value         float64
+	TwitterHandle string
+}
+
+type Snapshot struct {
+	Snapshot []*Ranking
+}
+
+// FromRaw takes the raw list of values as returned from the rest
+// service and tries to convert it into a Ranking.
+func FromRaw(raw []interface{}) (*Ranking, error) {
+	if len(raw) < 7 {
+		return nil, fmt.Errorf("data slice too short for ranking: %#v", raw)
+	}
+
+	r := &Ranking{
+		MTS:      convert.I64ValOrZero(raw[0]),
+		Username: convert.SValOrEmpty(raw[2]),
+		Ranking:  convert.I64ValOrZero(raw[3]),
+		Value:    convert.F64ValOrZero(raw[6]),
+	}
+
+	if len(raw) > 9 {
+		r.TwitterHandle = convert.SValOrEmpty(raw[9])
+	}
+
+	return r, nil
+}
+
+// SnapshotFromRaw takes a raw list of values as returned from the rest
+// service and tries to convert it into a Snapshot.
+func SnapshotFromRaw(raw []interface{}) (*Snapshot, error) {
+	rs := make([]*Ranking, 0, len(raw))
+	for _, v := range raw {
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected ranking slice, got: %#v", v)
+		}
+
+		r, err := FromRaw(l)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+
+	return &Snapshot{Snapshot: rs}, nil
+}
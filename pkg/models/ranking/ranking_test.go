@@ -0,0 +1,69 @@
+package ranking_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ranking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromRaw(t *testing.T) {
+	t.Run("insufficient arguments", func(t *testing.T) {
+		r, err := ranking.FromRaw([]interface{}{1609144352338})
+		require.NotNil(t, err)
+		require.Nil(t, r)
+	})
+
+	t.Run("valid arguments", func(t *testing.T) {
+		r, err := ranking.FromRaw([]interface{}{
+			1609144352338, nil, "trader", 1, nil, nil, 12345.67, nil, nil, "trader_twitter",
+		})
+		require.Nil(t, err)
+
+		expected := &ranking.Ranking{
+			MTS:           1609144352338,
+			Username:      "trader",
+			Ranking:       1,
+			Value:         12345.67,
+			TwitterHandle: "trader_twitter",
+		}
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("without twitter handle", func(t *testing.T) {
+		r, err := ranking.FromRaw([]interface{}{1609144352338, nil, "trader", 2, nil, nil, 100.5})
+		require.Nil(t, err)
+		assert.Equal(t, int64(2), r.Ranking)
+		assert.Equal(t, "", r.TwitterHandle)
+	})
+}
+
+func TestSnapshotFromRaw(t *testing.T) {
+	t.Run("no rankings", func(t *testing.T) {
+		s, err := ranking.SnapshotFromRaw([]interface{}{})
+		require.Nil(t, err)
+		assert.Empty(t, s.Snapshot)
+	})
+
+	t.Run("invalid ranking", func(t *testing.T) {
+		s, err := ranking.SnapshotFromRaw([]interface{}{[]interface{}{1}})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+
+		s, err = ranking.SnapshotFromRaw([]interface{}{"trader"})
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("valid rankings", func(t *testing.T) {
+		s, err := ranking.SnapshotFromRaw([]interface{}{
+			[]interface{}{1609144352338, nil, "first", 1, nil, nil, 200.5, nil, nil, nil},
+			[]interface{}{1609144352338, nil, "second", 2, nil, nil, 100.5, nil, nil, "second_twitter"},
+		})
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+		assert.Equal(t, "first", s.Snapshot[0].Username)
+		assert.Equal(t, "second_twitter", s.Snapshot[1].TwitterHandle)
+	})
+}
@@ -27,7 +27,7 @@ type Derivative struct {
 }
 
 func DerivFromRaw(symbol string, raw []interface{}) (*Derivative, error) {
-	if len(raw) < 22 {
+	if len(raw) < 23 {
 		return nil, fmt.Errorf("data slice too short for derivative status: %#v", raw)
 	}
 
@@ -69,5 +69,10 @@ func DerivFromRestRaw(raw []interface{}) (t *Derivative, err error) {
 		return t, fmt.Errorf("data slice too short for derivatives: %#v", raw)
 	}
 
-	return DerivFromRaw(raw[0].(string), raw[1:])
+	key, ok := raw[0].(string)
+	if !ok {
+		return t, fmt.Errorf("expected derivatives key, got: %#v", raw[0])
+	}
+
+	return DerivFromRaw(key, raw[1:])
 }
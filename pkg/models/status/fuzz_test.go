@@ -0,0 +1,115 @@
+//go:build go1.18
+// +build go1.18
+
+package status_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/status"
+)
+
+func FuzzDerivFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCF0:USTF0", `[]`},
+		{"tBTCF0:USTF0", `[null]`},
+		{"tBTCF0:USTF0", `[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = status.DerivFromRaw(symbol, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzDerivSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCF0:USTF0", `[]`},
+		{"tBTCF0:USTF0", `[null]`},
+		{"tBTCF0:USTF0", `[[]]`},
+		{"tBTCF0:USTF0", `[[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = status.DerivSnapshotFromRaw(symbol, snap)
+	})
+}
+
+func FuzzDerivFromRestRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCF0:USTF0",1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = status.DerivFromRestRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzLiqFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = status.LiqFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzLiqSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = status.LiqSnapshotFromRaw(snap)
+	})
+}
+
+func FuzzLiqHistoryFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[[null]]`,
+		`[[["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = status.LiqHistoryFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFromWSRaw(f *testing.F) {
+	for _, seed := range []struct{ key, data string }{
+		{"deriv:tBTCF0:USTF0", `[]`},
+		{"deriv:tBTCF0:USTF0", `[null]`},
+		{"deriv:tBTCF0:USTF0", `[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`},
+		{"deriv:tBTCF0:USTF0", `[[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]]`},
+		{"liq:global", `[]`},
+		{"liq:global", `[null]`},
+		{"liq:global", `["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]`},
+		{"liq:global", `[["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]]`},
+		{"deriv", `[1596124822000,null,0.896,0.9,null,1000,null,1596124800000,0.0001,0,null,0.0003,null,null,0.8,null,null,1500,null,null,null,0.5,0.1]`},
+	} {
+		f.Add(seed.key, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, key, data string) {
+		_, _ = status.FromWSRaw(key, fuzzutil.Decode(t, data))
+	})
+}
@@ -53,3 +53,28 @@ func LiqSnapshotFromRaw(raw [][]interface{}) (*LiquidationsSnapshot, error) {
 	}
 	return &LiquidationsSnapshot{Snapshot: snapshot}, nil
 }
+
+// LiqHistoryFromRaw takes the raw list of values as returned from the
+// liquidations history endpoint, where every liquidation comes wrapped in a
+// single element list, and tries to convert it into a LiquidationsSnapshot.
+func LiqHistoryFromRaw(raw []interface{}) (*LiquidationsSnapshot, error) {
+	snapshot := make([]*Liquidation, 0, len(raw))
+	for _, v := range raw {
+		wrapped, ok := v.([]interface{})
+		if !ok || len(wrapped) == 0 {
+			return nil, fmt.Errorf("expected wrapped liquidation slice, got: %#v", v)
+		}
+
+		r, ok := wrapped[0].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected liquidation slice, got: %#v", wrapped[0])
+		}
+
+		l, err := LiqFromRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = append(snapshot, l)
+	}
+	return &LiquidationsSnapshot{Snapshot: snapshot}, nil
+}
@@ -138,3 +138,81 @@ func TestLiqSnapshotFromRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestLiqHistoryFromRaw(t *testing.T) {
+	testCases := map[string]struct {
+		data    []interface{}
+		err     func(*testing.T, error)
+		success func(*testing.T, interface{})
+	}{
+		"empty slice": {
+			data: []interface{}{},
+			err: func(t *testing.T, got error) {
+				assert.Nil(t, got)
+			},
+			success: func(t *testing.T, got interface{}) {
+				assert.Equal(t, got, &status.LiquidationsSnapshot{Snapshot: []*status.Liquidation{}})
+			},
+		},
+		"not wrapped pld": {
+			data: []interface{}{
+				[]interface{}{
+					"pos", 145400868, 1609144352338, nil, "tETHF0:USTF0",
+					-1.67288094, 730.96, nil, 1, 1, nil, 736.13,
+				},
+			},
+			err: func(t *testing.T, got error) {
+				assert.Error(t, got)
+			},
+			success: func(t *testing.T, got interface{}) {
+				assert.Nil(t, got)
+			},
+		},
+		"invalid pld": {
+			data: []interface{}{[]interface{}{[]interface{}{1}}},
+			err: func(t *testing.T, got error) {
+				assert.Error(t, got)
+			},
+			success: func(t *testing.T, got interface{}) {
+				assert.Nil(t, got)
+			},
+		},
+		"valid pld": {
+			data: []interface{}{
+				[]interface{}{
+					[]interface{}{
+						"pos", 145400868, 1609144352338, nil, "tETHF0:USTF0",
+						-1.67288094, 730.96, nil, 1, 1, nil, 736.13,
+					},
+				},
+			},
+			err: func(t *testing.T, got error) {
+				assert.Nil(t, got)
+			},
+			success: func(t *testing.T, got interface{}) {
+				assert.Equal(t, got, &status.LiquidationsSnapshot{
+					Snapshot: []*status.Liquidation{
+						{
+							Symbol:        "tETHF0:USTF0",
+							PositionID:    145400868,
+							MTS:           1609144352338,
+							Amount:        -1.67288094,
+							BasePrice:     730.96,
+							IsMatch:       1,
+							IsMarketSold:  1,
+							PriceAcquired: 736.13,
+						},
+					},
+				})
+			},
+		},
+	}
+
+	for k, v := range testCases {
+		t.Run(k, func(t *testing.T) {
+			got, err := status.LiqHistoryFromRaw(v.data)
+			v.err(t, err)
+			v.success(t, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+//go:build go1.18
+// +build go1.18
+
+package ticker_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ticker"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = ticker.FromRaw(symbol, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[[]]`},
+		{"fUSD", `[[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = ticker.SnapshotFromRaw(symbol, snap)
+	})
+}
+
+func FuzzFromRestRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD",7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]`,
+		`["fUSD",0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = ticker.FromRestRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFromWSRaw(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]`},
+		{"tBTCUSD", `[[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]`},
+		{"fUSD", `[[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = ticker.FromWSRaw(symbol, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFromJSON(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = ticker.FromJSON(symbol, []byte(data))
+	})
+}
+
+func FuzzSnapshotFromJSON(f *testing.F) {
+	for _, seed := range []struct{ symbol, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[7245.6,34.3,7245.7,47.1,-25.8,-0.0036,7245.7,2112.8,7345.6,7181.2]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[[]]`},
+		{"fUSD", `[[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]]`},
+	} {
+		f.Add(seed.symbol, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, symbol, data string) {
+		_, _ = ticker.SnapshotFromJSON(symbol, []byte(data))
+	})
+}
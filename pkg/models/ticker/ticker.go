@@ -51,54 +51,106 @@ func SnapshotFromRaw(symbol string, raw [][]interface{}) (*Snapshot, error) {
 }
 
 func FromRaw(symbol string, raw []interface{}) (t *Ticker, err error) {
+	var buf [16]float64
+	t = &Ticker{}
+	if !fill(t, symbol, convert.F64ValsOrZero(buf[:0], raw)) {
+		return nil, fmt.Errorf("unrecognized data slice format for pair:%s, data:%#v", symbol, raw)
+	}
+	return
+}
+
+// FromJSON creates ticker from raw json in a single pass, without decoding it
+// into intermediate values
+func FromJSON(symbol string, data []byte) (*Ticker, error) {
+	t := &Ticker{}
+	if err := fillFromJSON(t, symbol, data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SnapshotFromJSON creates ticker snapshot from raw json, see FromJSON
+func SnapshotFromJSON(symbol string, data []byte) (*Snapshot, error) {
+	rawTickers, err := convert.SplitArray(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawTickers) == 0 {
+		return nil, fmt.Errorf("data slice too short for ticker snapshot: %s", data)
+	}
+
+	tickers := make([]Ticker, len(rawTickers))
+	snap := make([]*Ticker, len(rawTickers))
+	for i, v := range rawTickers {
+		if err := fillFromJSON(&tickers[i], symbol, v); err != nil {
+			return nil, err
+		}
+		snap[i] = &tickers[i]
+	}
+
+	return &Snapshot{Snapshot: snap}, nil
+}
+
+func fillFromJSON(t *Ticker, symbol string, data []byte) error {
+	var buf [16]float64
+	vals, err := convert.SplitF64Array(buf[:0], data)
+	if err != nil {
+		return err
+	}
+	if !fill(t, symbol, vals) {
+		return fmt.Errorf("unrecognized data slice format for pair:%s, data:%s", symbol, data)
+	}
+	return nil
+}
+
+// fill sets ticker fields from values of trading or funding pair ticker.
+// Returns false if values do not match symbol
+func fill(t *Ticker, symbol string, vals []float64) bool {
 	// trading pair update / snapshot
-	if strings.HasPrefix(symbol, "t") && len(raw) >= 10 {
-		t = &Ticker{
+	if strings.HasPrefix(symbol, "t") && len(vals) >= 10 {
+		*t = Ticker{
 			Symbol:          symbol,
-			Bid:             convert.F64ValOrZero(raw[0]),
-			BidSize:         convert.F64ValOrZero(raw[1]),
-			Ask:             convert.F64ValOrZero(raw[2]),
-			AskSize:         convert.F64ValOrZero(raw[3]),
-			DailyChange:     convert.F64ValOrZero(raw[4]),
-			DailyChangePerc: convert.F64ValOrZero(raw[5]),
-			LastPrice:       convert.F64ValOrZero(raw[6]),
-			Volume:          convert.F64ValOrZero(raw[7]),
-			High:            convert.F64ValOrZero(raw[8]),
-			Low:             convert.F64ValOrZero(raw[9]),
+			Bid:             vals[0],
+			BidSize:         vals[1],
+			Ask:             vals[2],
+			AskSize:         vals[3],
+			DailyChange:     vals[4],
+			DailyChangePerc: vals[5],
+			LastPrice:       vals[6],
+			Volume:          vals[7],
+			High:            vals[8],
+			Low:             vals[9],
 		}
-		return
+		return true
 	}
 
 	// funding pair update
-	if strings.HasPrefix(symbol, "f") {
-		if len(raw) >= 13 {
-			t = &Ticker{
-				Symbol:          symbol,
-				Frr:             convert.F64ValOrZero(raw[0]),
-				Bid:             convert.F64ValOrZero(raw[1]),
-				BidPeriod:       convert.I64ValOrZero(raw[2]),
-				BidSize:         convert.F64ValOrZero(raw[3]),
-				Ask:             convert.F64ValOrZero(raw[4]),
-				AskPeriod:       convert.I64ValOrZero(raw[5]),
-				AskSize:         convert.F64ValOrZero(raw[6]),
-				DailyChange:     convert.F64ValOrZero(raw[7]),
-				DailyChangePerc: convert.F64ValOrZero(raw[8]),
-				LastPrice:       convert.F64ValOrZero(raw[9]),
-				Volume:          convert.F64ValOrZero(raw[10]),
-				High:            convert.F64ValOrZero(raw[11]),
-				Low:             convert.F64ValOrZero(raw[12]),
-			}
+	if strings.HasPrefix(symbol, "f") && len(vals) >= 13 {
+		*t = Ticker{
+			Symbol:          symbol,
+			Frr:             vals[0],
+			Bid:             vals[1],
+			BidPeriod:       int64(vals[2]),
+			BidSize:         vals[3],
+			Ask:             vals[4],
+			AskPeriod:       int64(vals[5]),
+			AskSize:         vals[6],
+			DailyChange:     vals[7],
+			DailyChangePerc: vals[8],
+			LastPrice:       vals[9],
+			Volume:          vals[10],
+			High:            vals[11],
+			Low:             vals[12],
 		}
 
 		// funding pair snapshot
-		if len(raw) >= 16 {
-			t.FrrAmountAvailable = convert.F64ValOrZero(raw[15])
+		if len(vals) >= 16 {
+			t.FrrAmountAvailable = vals[15]
 		}
-		return
+		return true
 	}
 
-	err = fmt.Errorf("unrecognized data slice format for pair:%s, data:%#v", symbol, raw)
-	return
+	return false
 }
 
 func FromRestRaw(raw []interface{}) (t *Ticker, err error) {
@@ -106,7 +158,12 @@ func FromRestRaw(raw []interface{}) (t *Ticker, err error) {
 		return t, fmt.Errorf("data slice too short for ticker")
 	}
 
-	return FromRaw(raw[0].(string), raw[1:])
+	symbol, ok := raw[0].(string)
+	if !ok {
+		return t, fmt.Errorf("expected ticker symbol, got: %#v", raw[0])
+	}
+
+	return FromRaw(symbol, raw[1:])
 }
 
 // FromWSRaw - based on condition will return snapshot of tickers or single tick
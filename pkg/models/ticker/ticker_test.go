@@ -1,8 +1,10 @@
 package ticker_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ticker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -345,3 +347,98 @@ func TestFromWSRaw(t *testing.T) {
 		assert.Equal(t, expected, got)
 	})
 }
+
+func TestFromJSON(t *testing.T) {
+	cases := map[string]struct {
+		symbol string
+		pld    string
+	}{
+		"trading pair":     {symbol: "tBTCUSD", pld: `[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]`},
+		"funding currency": {symbol: "fUSD", pld: `[0.0003447013698630137,0.000316,30,1682003.0922634401,0.00031783,4,23336.545380509996,0.00004331,0.1575,0.00031783,234034.52087847,0.00035,0.00025,null,null,1234.5]`},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			var raw []interface{}
+			require.Nil(t, json.Unmarshal([]byte(v.pld), &raw))
+			expected, err := ticker.FromRaw(v.symbol, raw)
+			require.Nil(t, err)
+
+			got, err := ticker.FromJSON(v.symbol, []byte(v.pld))
+			require.Nil(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+
+	t.Run("snapshot", func(t *testing.T) {
+		pld := []byte(`[[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500],[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]]`)
+		var raw []interface{}
+		require.Nil(t, json.Unmarshal(pld, &raw))
+		expected, err := ticker.SnapshotFromRaw("tBTCUSD", convert.ToInterfaceArray(raw))
+		require.Nil(t, err)
+
+		got, err := ticker.SnapshotFromJSON("tBTCUSD", pld)
+		require.Nil(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("invalid payloads", func(t *testing.T) {
+		for _, pld := range []string{`[]`, `[1,2,3]`, `[1,2,3,4`, `{"bid":1}`} {
+			got, err := ticker.FromJSON("tBTCUSD", []byte(pld))
+			assert.NotNil(t, err, pld)
+			assert.Nil(t, got)
+		}
+
+		got, err := ticker.SnapshotFromJSON("tBTCUSD", []byte(`[]`))
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func BenchmarkFromRaw(b *testing.B) {
+	pld := []byte(`[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var raw []interface{}
+		if err := json.Unmarshal(pld, &raw); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ticker.FromRaw("tBTCUSD", raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromJSON(b *testing.B) {
+	pld := []byte(`[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ticker.FromJSON("tBTCUSD", pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromRaw(b *testing.B) {
+	pld := []byte(`[[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var raw []interface{}
+		if err := json.Unmarshal(pld, &raw); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ticker.SnapshotFromRaw("tBTCUSD", convert.ToInterfaceArray(raw)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromJSON(b *testing.B) {
+	pld := []byte(`[[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ticker.SnapshotFromJSON("tBTCUSD", pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
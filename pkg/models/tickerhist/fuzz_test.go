@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package tickerhist_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/tickerhist"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["tBTCUSD",54281,null,54282,null,null,null,null,null,null,null,null,1619769715000]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = tickerhist.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[["tBTCUSD",54281,null,54282,null,null,null,null,null,null,null,null,1619769715000]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_ = tickerhist.SnapshotFromRaw(snap)
+	})
+}
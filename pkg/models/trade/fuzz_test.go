@@ -0,0 +1,99 @@
+//go:build go1.18
+// +build go1.18
+
+package trade_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/trade"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trade.FromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[401597393,1574694475039,0.005,7244.9]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[[]]`},
+		{"fUSD", `[[133323072,1574694245478,-258.7458086,0.0002587,2]]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = trade.SnapshotFromRaw(pair, snap)
+	})
+}
+
+func FuzzFromWSRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+		{"tBTCUSD", `[[401597393,1574694475039,0.005,7244.9]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+		{"fUSD", `[[133323072,1574694245478,-258.7458086,0.0002587,2]]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trade.FromWSRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFromJSON(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trade.FromJSON(pair, []byte(data))
+	})
+}
+
+func FuzzSnapshotFromJSON(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[401597393,1574694475039,0.005,7244.9]]`},
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[[]]`},
+		{"fUSD", `[[133323072,1574694245478,-258.7458086,0.0002587,2]]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trade.SnapshotFromJSON(pair, []byte(data))
+	})
+}
@@ -24,30 +24,11 @@ type Snapshot struct {
 }
 
 func FromRaw(pair string, raw []interface{}) (t *Trade, err error) {
-	if strings.HasPrefix(pair, "t") && len(raw) >= 4 {
-		t = &Trade{
-			Pair:   pair,
-			ID:     convert.I64ValOrZero(raw[0]),
-			MTS:    convert.I64ValOrZero(raw[1]),
-			Amount: convert.F64ValOrZero(raw[2]),
-			Price:  convert.F64ValOrZero(raw[3]),
-		}
-		return
+	var buf [8]float64
+	t = &Trade{}
+	if !fill(t, pair, convert.F64ValsOrZero(buf[:0], raw)) {
+		return nil, fmt.Errorf("data slice too short for %s pair: %#v", pair, raw)
 	}
-
-	if strings.HasPrefix(pair, "f") && len(raw) >= 5 {
-		t = &Trade{
-			Pair:   pair,
-			ID:     convert.I64ValOrZero(raw[0]),
-			MTS:    convert.I64ValOrZero(raw[1]),
-			Amount: convert.F64ValOrZero(raw[2]),
-			Rate:   convert.F64ValOrZero(raw[3]),
-			Period: convert.ToInt(raw[4]),
-		}
-		return
-	}
-
-	err = fmt.Errorf("data slice too short for %s pair: %#v", pair, raw)
 	return
 }
 
@@ -68,6 +49,79 @@ func SnapshotFromRaw(pair string, raw [][]interface{}) (*Snapshot, error) {
 	return &Snapshot{Snapshot: snapshot}, nil
 }
 
+// FromJSON creates trade from raw json in a single pass, without decoding it
+// into intermediate values
+func FromJSON(pair string, data []byte) (*Trade, error) {
+	t := &Trade{}
+	if err := fillFromJSON(t, pair, data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SnapshotFromJSON creates trade snapshot from raw json, see FromJSON
+func SnapshotFromJSON(pair string, data []byte) (*Snapshot, error) {
+	rawTrades, err := convert.SplitArray(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawTrades) == 0 {
+		return nil, fmt.Errorf("data slice is too short for trade snapshot: %s", data)
+	}
+
+	trades := make([]Trade, len(rawTrades))
+	snapshot := make([]*Trade, len(rawTrades))
+	for i, v := range rawTrades {
+		if err := fillFromJSON(&trades[i], pair, v); err != nil {
+			return nil, err
+		}
+		snapshot[i] = &trades[i]
+	}
+
+	return &Snapshot{Snapshot: snapshot}, nil
+}
+
+func fillFromJSON(t *Trade, pair string, data []byte) error {
+	var buf [8]float64
+	vals, err := convert.SplitF64Array(buf[:0], data)
+	if err != nil {
+		return err
+	}
+	if !fill(t, pair, vals) {
+		return fmt.Errorf("data slice too short for %s pair: %s", pair, data)
+	}
+	return nil
+}
+
+// fill sets trade fields from values of trading or funding pair trade.
+// Returns false if there are not enough of them
+func fill(t *Trade, pair string, vals []float64) bool {
+	if strings.HasPrefix(pair, "t") && len(vals) >= 4 {
+		*t = Trade{
+			Pair:   pair,
+			ID:     int64(vals[0]),
+			MTS:    int64(vals[1]),
+			Amount: vals[2],
+			Price:  vals[3],
+		}
+		return true
+	}
+
+	if strings.HasPrefix(pair, "f") && len(vals) >= 5 {
+		*t = Trade{
+			Pair:   pair,
+			ID:     int64(vals[0]),
+			MTS:    int64(vals[1]),
+			Amount: vals[2],
+			Rate:   vals[3],
+			Period: int(vals[4]),
+		}
+		return true
+	}
+
+	return false
+}
+
 // FromWSRaw - based on condition will return snapshot of trades or single trade
 func FromWSRaw(pair string, data []interface{}) (interface{}, error) {
 	if len(data) == 0 {
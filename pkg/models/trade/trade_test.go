@@ -1,8 +1,10 @@
 package trade_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/trade"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -218,3 +220,99 @@ func TestFromWSRaw(t *testing.T) {
 		assert.Equal(t, expected, got)
 	})
 }
+
+func TestFromJSON(t *testing.T) {
+	cases := map[string]struct {
+		symbol string
+		pld    string
+	}{
+		"trading pair":     {symbol: "tBTCUSD", pld: `[401597395,1574694478808,0.005,7245.3]`},
+		"funding currency": {symbol: "fUSD", pld: `[133323543,1574694605000,-59.84,0.00023647,2]`},
+		"null fields":      {symbol: "tBTCUSD", pld: `[401597395,null,0.005,"7245.3"]`},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			var raw []interface{}
+			require.Nil(t, json.Unmarshal([]byte(v.pld), &raw))
+			expected, err := trade.FromRaw(v.symbol, raw)
+			require.Nil(t, err)
+
+			got, err := trade.FromJSON(v.symbol, []byte(v.pld))
+			require.Nil(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+
+	t.Run("snapshot", func(t *testing.T) {
+		pld := []byte(`[[401597395,1574694478808,0.005,7245.3],[401597396,1574694478809,-0.1,7245.2]]`)
+		var raw []interface{}
+		require.Nil(t, json.Unmarshal(pld, &raw))
+		expected, err := trade.SnapshotFromRaw("tBTCUSD", convert.ToInterfaceArray(raw))
+		require.Nil(t, err)
+
+		got, err := trade.SnapshotFromJSON("tBTCUSD", pld)
+		require.Nil(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("invalid payloads", func(t *testing.T) {
+		for _, pld := range []string{`[]`, `[1,2,3]`, `[1,2,3,4`, `{"id":1}`} {
+			got, err := trade.FromJSON("tBTCUSD", []byte(pld))
+			assert.NotNil(t, err, pld)
+			assert.Nil(t, got)
+		}
+
+		got, err := trade.SnapshotFromJSON("tBTCUSD", []byte(`[]`))
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func BenchmarkFromRaw(b *testing.B) {
+	pld := []byte(`[401597395,1574694478808,0.005,7245.3]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var raw []interface{}
+		if err := json.Unmarshal(pld, &raw); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := trade.FromRaw("tBTCUSD", raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromJSON(b *testing.B) {
+	pld := []byte(`[401597395,1574694478808,0.005,7245.3]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := trade.FromJSON("tBTCUSD", pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromRaw(b *testing.B) {
+	pld := []byte(`[[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3]]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var raw []interface{}
+		if err := json.Unmarshal(pld, &raw); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := trade.SnapshotFromRaw("tBTCUSD", convert.ToInterfaceArray(raw)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnapshotFromJSON(b *testing.B) {
+	pld := []byte(`[[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3],[401597395,1574694478808,0.005,7245.3]]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := trade.SnapshotFromJSON("tBTCUSD", pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+//go:build go1.18
+// +build go1.18
+
+package trades_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/trades"
+)
+
+func FuzzFromWSRaw(f *testing.F) {
+	// type is read from raw while payload comes from data, so their
+	// lengths are fuzzed independently
+	for _, seed := range []struct{ pair, raw, data string }{
+		{"tBTCUSD", `[]`, `[]`},
+		{"tBTCUSD", `[17470]`, `[null]`},
+		{"tBTCUSD", `[17470,[]]`, `[[]]`},
+		{"tBTCUSD", `[17470,[[401597393,1574694475039,0.005,7244.9]]]`, `[[401597393,1574694475039,0.005,7244.9]]`},
+		{"fUSD", `[337371,[[133323072,1574694245478,-258.7458086,0.0002587,2]]]`, `[[133323072,1574694245478,-258.7458086,0.0002587,2]]`},
+		{"tBTCUSD", `[17470,"te",[401597395,1574694478808,0.005,7245.3]]`, `[401597395,1574694478808,0.005,7245.3]`},
+		{"tBTCUSD", `[17470,"tu",[401597395,1574694478808,0.005,7245.3]]`, `[401597395,1574694478808,0.005,7245.3]`},
+		{"fUSD", `[337371,"fte",[133323543,1574694605000,-59.84,0.00023647,2]]`, `[133323543,1574694605000,-59.84,0.00023647,2]`},
+		{"fUSD", `[337371,"ftu",[133323543,1574694605000,-59.84,0.00023647,2]]`, `[133323543,1574694605000,-59.84,0.00023647,2]`},
+		{"tBTCUSD", `[17470,"te",[]]`, `[null]`},
+		{"tBTCUSD", `[17470,null,[]]`, `[401597395,1574694478808,0.005,7245.3]`},
+		{"tBTCUSD", `[17470,"hb"]`, `[401597395,1574694478808,0.005,7245.3]`},
+	} {
+		f.Add(seed.pair, seed.raw, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, raw, data string) {
+		_, _ = trades.FromWSRaw(pair, fuzzutil.Decode(t, raw), fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzTFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.TFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzTEFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.TEFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzTEUFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[401597393,1574694475039,0.005,7244.9]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.TEUFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzTSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"tBTCUSD", `[]`},
+		{"tBTCUSD", `[null]`},
+		{"tBTCUSD", `[[]]`},
+		{"tBTCUSD", `[[401597393,1574694475039,0.005,7244.9]]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = trades.TSnapshotFromRaw(pair, snap)
+	})
+}
+
+func FuzzFTFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.FTFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFTEFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.FTEFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFTEUFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[133323072,1574694245478,-258.7458086,0.0002587,2]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		_, _ = trades.FTEUFromRaw(pair, fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzFTSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []struct{ pair, data string }{
+		{"fUSD", `[]`},
+		{"fUSD", `[null]`},
+		{"fUSD", `[[]]`},
+		{"fUSD", `[[133323072,1574694245478,-258.7458086,0.0002587,2]]`},
+	} {
+		f.Add(seed.pair, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, pair, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = trades.FTSnapshotFromRaw(pair, snap)
+	})
+}
+
+func FuzzAFTFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636854,"fUSD",1575282446000,41238905,-1000,0.002,7,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = trades.AFTFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzAFTEFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636854,"fUSD",1575282446000,41238905,-1000,0.002,7,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = trades.AFTEFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzAFTUFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[636854,"fUSD",1575282446000,41238905,-1000,0.002,7,null]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = trades.AFTUFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzAFTSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[[]]`,
+		`[[636854,"fUSD",1575282446000,41238905,-1000,0.002,7,null]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		snap := convert.ToInterfaceArray(fuzzutil.Decode(t, data))
+		_, _ = trades.AFTSnapshotFromRaw(snap)
+	})
+}
+
+func FuzzATEFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[402088407,"tETHUST",1574963975602,34938060782,-0.2,153.57,"MARKET",0,-1,null,null,0]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = trades.ATEFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzATEUFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`[402088407,"tETHUST",1574963975602,34938060782,-0.2,153.57,"MARKET",0,-1,-0.061668,"USD"]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = trades.ATEUFromRaw(fuzzutil.Decode(t, data))
+	})
+}
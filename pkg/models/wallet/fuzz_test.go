@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package wallet_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/internal/fuzzutil"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/wallet"
+)
+
+func FuzzFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["exchange","UST",19788.6529257,0,19788.6529257,"Exchange 2.0 UST for USD @ 11.696",{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = wallet.FromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzUpdateFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["exchange","UST",19788.6529257,0,19788.6529257,"Exchange 2.0 UST for USD @ 11.696",{"reason":"TRADE","order_id":34271018124}]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = wallet.UpdateFromRaw(fuzzutil.Decode(t, data))
+	})
+}
+
+func FuzzSnapshotFromRaw(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`["exchange","UST",19788.6529257,0,19788.6529257,"Exchange 2.0 UST for USD @ 11.696",{"reason":"TRADE","order_id":34271018124}]`,
+		`[["exchange","UST",19788.6529257,0,19788.6529257,"Exchange 2.0 UST for USD @ 11.696",{"reason":"TRADE","order_id":34271018124}]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		_, _ = wallet.SnapshotFromRaw(fuzzutil.Decode(t, data))
+	})
+}
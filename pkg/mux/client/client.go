@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"sync"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
@@ -19,9 +20,30 @@ import (
 type Client struct {
 	id        int
 	conn      net.Conn
-	nonceGen  *utils.EpochNonceGenerator
+	nonceGen  utils.NonceGenerator
+	dialer    Dialer
+	flags     int
 	subsLimit int
 	subs      map[event.Subscribe]bool
+	filter    []string
+	done      chan struct{}
+	closeOnce sync.Once
+	// subsMtx guards subs, writeMtx serializes frames written to conn
+	subsMtx  sync.RWMutex
+	writeMtx sync.Mutex
+}
+
+// authRequest extends auth event with channel filters. Filters are kept
+// out of event.Subscribe since subscriptions are used as map keys
+type authRequest struct {
+	event.Subscribe
+	Filter []string `json:"filter,omitempty"`
+}
+
+// confRequest enables configuration flags on connection
+type confRequest struct {
+	Event string `json:"event"`
+	Flags int    `json:"flags"`
 }
 
 // New returns pointer to Client instance
@@ -29,6 +51,8 @@ func New() *Client {
 	return &Client{
 		subs:     make(map[event.Subscribe]bool),
 		nonceGen: utils.NewEpochNonceGenerator(),
+		dialer:   DefaultDialer,
+		done:     make(chan struct{}),
 	}
 }
 
@@ -44,20 +68,55 @@ func (c *Client) WithSubsLimit(limit int) *Client {
 	return c
 }
 
+// WithNonceGenerator sets generator of auth nonces. Nonce has to keep
+// increasing across connections made with the same api key
+func (c *Client) WithNonceGenerator(gen utils.NonceGenerator) *Client {
+	c.nonceGen = gen
+	return c
+}
+
+// WithDialer sets dialer used to connect to api, DefaultDialer if nil
+func (c *Client) WithDialer(d Dialer) *Client {
+	if d != nil {
+		c.dialer = d
+	}
+	return c
+}
+
+// WithFlags sets configuration flags, like common.Checksum, sent
+// to public api right after connecting
+func (c *Client) WithFlags(flags int) *Client {
+	c.flags = flags
+	return c
+}
+
+// WithFilter sets filters applied to authenticated channel
+func (c *Client) WithFilter(filter []string) *Client {
+	c.filter = filter
+	return c
+}
+
 // Public creates and returns client to interact with public channels
 func (c *Client) Public(url string) (*Client, error) {
-	conn, _, _, err := ws.DefaultDialer.Dial(context.Background(), url)
+	conn, err := c.dialer.Dial(context.Background(), url)
 	if err != nil {
 		return nil, err
 	}
 	c.conn = conn
+
+	if c.flags != 0 {
+		if err := c.Send(confRequest{Event: "conf", Flags: c.flags}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
 // Private creates and returns client to interact with private channels
 func (c *Client) Private(key, sec, url string, dms int) (*Client, error) {
 	nonce := c.nonceGen.GetNonce()
-	conn, _, _, err := ws.DefaultDialer.Dial(context.Background(), url)
+	conn, err := c.dialer.Dial(context.Background(), url)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +125,7 @@ func (c *Client) Private(key, sec, url string, dms int) (*Client, error) {
 	payload := "AUTH" + nonce
 	sig := hmac.New(sha512.New384, []byte(sec))
 	if _, err := sig.Write([]byte(payload)); err != nil {
+		conn.Close()
 		return nil, err
 	}
 
@@ -79,9 +139,12 @@ func (c *Client) Private(key, sec, url string, dms int) (*Client, error) {
 		DMS:         dms,
 	}
 
-	if err := c.Subscribe(sub); err != nil {
+	if err := c.Send(authRequest{Subscribe: sub, Filter: c.filter}); err != nil {
+		conn.Close()
 		return nil, err
 	}
+
+	c.AddSub(sub)
 	return c, nil
 }
 
@@ -120,15 +183,22 @@ func (c *Client) Send(pld interface{}) error {
 		return err
 	}
 
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
 	return wsutil.WriteClientBinary(c.conn, b)
 }
 
-// Close closes the socket connection
-func (c *Client) Close() error {
-	return c.conn.Close()
+// Close closes the socket connection and stops Read. Subsequent calls are no-op
+func (c *Client) Close() (err error) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.conn.Close()
+	})
+	return
 }
 
-// Read starts consuming data stream
+// Read starts consuming data stream. Returns once connection fails or
+// client is closed, in which case nothing more is sent to ch
 func (c *Client) Read(ch chan<- msg.Msg) {
 	defer c.conn.Close()
 
@@ -138,17 +208,35 @@ func (c *Client) Read(ch chan<- msg.Msg) {
 
 		if err != nil {
 			m.Err = err
-			ch <- m
+			c.publish(ch, m)
 			return
 		}
 
 		if opCode == ws.OpClose {
 			m.Err = errors.New("client has closed unexpectedly")
-			ch <- m
+			c.publish(ch, m)
+			return
+		}
+
+		if !c.publish(ch, m) {
 			return
 		}
+	}
+}
 
-		ch <- m
+// publish sends message to ch unless client gets closed first
+func (c *Client) publish(ch chan<- msg.Msg, m msg.Msg) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case ch <- m:
+		return true
+	case <-c.done:
+		return false
 	}
 }
 
@@ -157,28 +245,38 @@ func (c *Client) SubsLimitReached() bool {
 	if c.subsLimit == 0 {
 		return false
 	}
+	c.subsMtx.RLock()
+	defer c.subsMtx.RUnlock()
 	return len(c.subs) == c.subsLimit
 }
 
 // SubAdded checks if given subscription is already added. Used to
 // avoid duplicate subscriptions per client
 func (c *Client) SubAdded(sub event.Subscribe) (isAdded bool) {
+	c.subsMtx.RLock()
+	defer c.subsMtx.RUnlock()
 	_, isAdded = c.subs[sub]
 	return
 }
 
 // AddSub adds new subscription to the list
 func (c *Client) AddSub(sub event.Subscribe) {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
 	c.subs[sub] = true
 }
 
 // RemoveSub removes new subscription to the list
 func (c *Client) RemoveSub(sub event.Subscribe) {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
 	delete(c.subs, sub)
 }
 
 // GetAllSubs returns all subscriptions
 func (c *Client) GetAllSubs() (res []event.Subscribe) {
+	c.subsMtx.RLock()
+	defer c.subsMtx.RUnlock()
 	for sub := range c.subs {
 		res = append(res, sub)
 	}
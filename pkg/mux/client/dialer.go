@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+
+	"github.com/gobwas/ws"
+)
+
+// Dialer opens connection to websocket api at given url. Returned
+// connection must have websocket handshake completed
+type Dialer interface {
+	Dial(ctx context.Context, url string) (net.Conn, error)
+}
+
+// DialerFunc allows use of an ordinary function as Dialer
+type DialerFunc func(ctx context.Context, url string) (net.Conn, error)
+
+// Dial calls f(ctx, url)
+func (f DialerFunc) Dial(ctx context.Context, url string) (net.Conn, error) {
+	return f(ctx, url)
+}
+
+// DefaultDialer dials api directly, with default gobwas/ws settings
+var DefaultDialer = WSDialer(ws.DefaultDialer)
+
+// WSDialer returns Dialer performing websocket handshake with given gobwas/ws
+// dialer. Use it for custom TLS config, headers, timeouts or NetDial
+func WSDialer(d ws.Dialer) Dialer {
+	return DialerFunc(func(ctx context.Context, url string) (net.Conn, error) {
+		conn, br, _, err := d.Dial(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		// server might have sent frames right after handshake response
+		if br != nil {
+			return newBufferedConn(conn, br), nil
+		}
+		return conn, nil
+	})
+}
+
+// bufferedConn reads data buffered during handshake before reading conn
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// newBufferedConn wraps conn only if reader holds unread data
+func newBufferedConn(conn net.Conn, br *bufio.Reader) net.Conn {
+	if br.Buffered() == 0 {
+		return conn
+	}
+	return &bufferedConn{Conn: conn, r: io.MultiReader(io.LimitReader(br, int64(br.Buffered())), conn)}
+}
@@ -0,0 +1,268 @@
+package client_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEchoServer starts websocket server sending every client message back
+func newEchoServer(t *testing.T) (*httptest.Server, string) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _, err := ws.UpgradeHTTP(r, w)
+		if err != nil {
+			t.Errorf("upgrade failed: %s", err)
+			return
+		}
+		defer conn.Close()
+
+		for {
+			req, _, err := wsutil.ReadClientData(conn)
+			if err != nil {
+				return
+			}
+			if err := wsutil.WriteServerText(conn, req); err != nil {
+				return
+			}
+		}
+	}))
+	return srv, "ws://" + strings.TrimPrefix(srv.URL, "http://")
+}
+
+// proxy accepts connections and tunnels them after handshake succeeds
+type proxy struct {
+	net.Listener
+	tunnels   int32
+	handshake func(conn net.Conn, br *bufio.Reader) (target string, err error)
+}
+
+func newProxy(t *testing.T, handshake func(conn net.Conn, br *bufio.Reader) (string, error)) *proxy {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	p := &proxy{Listener: l, handshake: handshake}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *proxy) serve(conn net.Conn) {
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	target, err := p.handshake(conn, br)
+	if err != nil {
+		return
+	}
+
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+	atomic.AddInt32(&p.tunnels, 1)
+
+	go io.Copy(upstream, br)
+	io.Copy(conn, upstream)
+}
+
+// httpProxy handles HTTP CONNECT, requiring given basic auth credentials
+func httpProxy(user, pass string) func(conn net.Conn, br *bufio.Reader) (string, error) {
+	return func(conn net.Conn, br *bufio.Reader) (string, error) {
+		req, err := http.ReadRequest(br)
+		if err != nil {
+			return "", err
+		}
+
+		// BasicAuth reads Authorization header only
+		auth := &http.Request{Header: http.Header{"Authorization": {req.Header.Get("Proxy-Authorization")}}}
+		u, p, ok := auth.BasicAuth()
+
+		if req.Method != http.MethodConnect || !ok || u != user || p != pass {
+			fmt.Fprint(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+			return "", fmt.Errorf("unauthorized")
+		}
+
+		fmt.Fprint(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		return req.Host, nil
+	}
+}
+
+// socks5Proxy handles socks5 CONNECT, requiring given credentials
+func socks5Proxy(user, pass string) func(conn net.Conn, br *bufio.Reader) (string, error) {
+	return func(conn net.Conn, br *bufio.Reader) (string, error) {
+		head := make([]byte, 2)
+		if _, err := io.ReadFull(br, head); err != nil {
+			return "", err
+		}
+		if _, err := io.ReadFull(br, make([]byte, head[1])); err != nil {
+			return "", err
+		}
+		conn.Write([]byte{5, 2})
+
+		// username/password sub negotiation
+		if _, err := io.ReadFull(br, head); err != nil {
+			return "", err
+		}
+		u := make([]byte, head[1])
+		io.ReadFull(br, u)
+		l, _ := br.ReadByte()
+		p := make([]byte, l)
+		io.ReadFull(br, p)
+		if string(u) != user || string(p) != pass {
+			conn.Write([]byte{1, 1})
+			return "", fmt.Errorf("unauthorized")
+		}
+		conn.Write([]byte{1, 0})
+
+		req := make([]byte, 4)
+		if _, err := io.ReadFull(br, req); err != nil {
+			return "", err
+		}
+
+		var host string
+		switch req[3] {
+		case 1:
+			ip := make([]byte, 4)
+			io.ReadFull(br, ip)
+			host = net.IP(ip).String()
+		case 3:
+			l, _ := br.ReadByte()
+			name := make([]byte, l)
+			io.ReadFull(br, name)
+			host = string(name)
+		}
+		port := make([]byte, 2)
+		io.ReadFull(br, port)
+
+		conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, port[0], port[1]})
+		return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+	}
+}
+
+// echo sends payload over the client and returns what server sent back
+func echo(t *testing.T, c *client.Client) string {
+	ch := make(chan msg.Msg, 1)
+	go c.Read(ch)
+	require.Nil(t, c.Send(map[string]string{"event": "ping"}))
+
+	select {
+	case m := <-ch:
+		require.Nil(t, m.Err)
+		return string(m.Data)
+	case <-time.After(time.Second):
+		t.Fatal("echo not received")
+	}
+	return ""
+}
+
+func TestDialers(t *testing.T) {
+	srv, url := newEchoServer(t)
+	defer srv.Close()
+
+	httpP := newProxy(t, httpProxy("user", "pass"))
+	defer httpP.Close()
+	socksP := newProxy(t, socks5Proxy("user", "pass"))
+	defer socksP.Close()
+
+	// localhost makes socks5 proxy resolve host name
+	socksURL := strings.Replace(url, "127.0.0.1", "localhost", 1)
+
+	cases := map[string]struct {
+		proxy    *proxy
+		proxyURL string
+		url      string
+	}{
+		"http connect": {
+			proxy:    httpP,
+			proxyURL: "http://user:pass@" + httpP.Addr().String(),
+			url:      url,
+		},
+		"socks5 with ip address": {
+			proxy:    socksP,
+			proxyURL: "socks5://user:pass@" + socksP.Addr().String(),
+			url:      url,
+		},
+		"socks5 with host name": {
+			proxy:    socksP,
+			proxyURL: "socks5://user:pass@" + socksP.Addr().String(),
+			url:      socksURL,
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			d, err := client.NewProxyDialer(v.proxyURL, ws.Dialer{Timeout: time.Second})
+			require.Nil(t, err)
+
+			before := atomic.LoadInt32(&v.proxy.tunnels)
+			c, err := client.New().WithDialer(d).Public(v.url)
+			require.Nil(t, err)
+			defer c.Close()
+
+			assert.Equal(t, `{"event":"ping"}`, echo(t, c))
+			assert.Equal(t, before+1, atomic.LoadInt32(&v.proxy.tunnels))
+		})
+	}
+
+	t.Run("direct", func(t *testing.T) {
+		c, err := client.New().Public(url)
+		require.Nil(t, err)
+		defer c.Close()
+		assert.Equal(t, `{"event":"ping"}`, echo(t, c))
+	})
+}
+
+func TestDialerErrors(t *testing.T) {
+	srv, url := newEchoServer(t)
+	defer srv.Close()
+
+	httpP := newProxy(t, httpProxy("user", "pass"))
+	defer httpP.Close()
+	socksP := newProxy(t, socks5Proxy("user", "pass"))
+	defer socksP.Close()
+
+	cases := map[string]string{
+		"http bad credentials":   "http://user:nope@" + httpP.Addr().String(),
+		"socks5 bad credentials": "socks5://user:nope@" + socksP.Addr().String(),
+		"socks5 no credentials":  "socks5://" + socksP.Addr().String(),
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			d, err := client.NewProxyDialer(v, ws.Dialer{Timeout: time.Second})
+			require.Nil(t, err)
+
+			_, err = client.New().WithDialer(d).Public(url)
+			assert.NotNil(t, err)
+		})
+	}
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := client.NewProxyDialer("ftp://"+httpP.Addr().String(), ws.Dialer{})
+		assert.NotNil(t, err)
+	})
+}
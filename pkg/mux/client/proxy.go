@@ -0,0 +1,281 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gobwas/ws"
+)
+
+// NewProxyDialer returns Dialer tunneling connections through proxy at given
+// url. Supported schemes are http and https (HTTP CONNECT) and socks5. User
+// info in proxy url is used for authentication. Settings of d other than
+// NetDial, like TLS config used towards api, are preserved
+func NewProxyDialer(proxyURL string, d ws.Dialer) (Dialer, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		d.NetDial = httpConnect(u)
+	case "socks5", "socks5h":
+		d.NetDial = socks5Connect(u)
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", u.Scheme)
+	}
+
+	return WSDialer(d), nil
+}
+
+// dialProxy opens connection to proxy itself, TLS one for https proxies
+func dialProxy(ctx context.Context, u *url.URL) (net.Conn, error) {
+	host := u.Host
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "http":
+			host = net.JoinHostPort(u.Hostname(), "80")
+		case "https":
+			host = net.JoinHostPort(u.Hostname(), "443")
+		default:
+			host = net.JoinHostPort(u.Hostname(), "1080")
+		}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "https" {
+		tc := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tc.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tc, nil
+	}
+	return conn, nil
+}
+
+// handshake runs fn with conn deadline taken from ctx, closing conn on failure
+func handshake(ctx context.Context, conn net.Conn, fn func() (net.Conn, error)) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	tunnel, err := fn()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tunnel, nil
+}
+
+func httpConnect(u *url.URL) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialProxy(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		return handshake(ctx, conn, func() (net.Conn, error) {
+			req := &http.Request{
+				Method: http.MethodConnect,
+				URL:    &url.URL{Opaque: addr},
+				Host:   addr,
+				Header: make(http.Header),
+			}
+			if u.User != nil {
+				pass, _ := u.User.Password()
+				creds := base64.StdEncoding.EncodeToString([]byte(u.User.Username() + ":" + pass))
+				req.Header.Set("Proxy-Authorization", "Basic "+creds)
+			}
+
+			if err := req.Write(conn); err != nil {
+				return nil, err
+			}
+
+			br := bufio.NewReader(conn)
+			res, err := http.ReadResponse(br, req)
+			if err != nil {
+				return nil, err
+			}
+			res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("proxy CONNECT %s failed: %s", addr, res.Status)
+			}
+			return newBufferedConn(conn, br), nil
+		})
+	}
+}
+
+// socks5 protocol constants, see RFC 1928 and RFC 1929
+const (
+	socks5Version      = 0x05
+	socks5NoAuth       = 0x00
+	socks5UserPass     = 0x02
+	socks5NoAcceptable = 0xff
+	socks5CmdConnect   = 0x01
+	socks5IPv4         = 0x01
+	socks5Domain       = 0x03
+	socks5IPv6         = 0x04
+)
+
+func socks5Connect(u *url.URL) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialProxy(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		return handshake(ctx, conn, func() (net.Conn, error) {
+			if err := socks5Auth(conn, u.User); err != nil {
+				return nil, err
+			}
+			if err := socks5Request(conn, addr); err != nil {
+				return nil, err
+			}
+			return conn, nil
+		})
+	}
+}
+
+func socks5Auth(conn net.Conn, user *url.Userinfo) error {
+	methods := []byte{socks5NoAuth}
+	if user != nil {
+		methods = append(methods, socks5UserPass)
+	}
+
+	greeting := append([]byte{socks5Version, byte(len(methods))}, methods...)
+	if _, err := conn.Write(greeting); err != nil {
+		return err
+	}
+
+	res := make([]byte, 2)
+	if _, err := io.ReadFull(conn, res); err != nil {
+		return err
+	}
+	if res[0] != socks5Version {
+		return fmt.Errorf("unexpected socks version: %d", res[0])
+	}
+
+	switch res[1] {
+	case socks5NoAuth:
+		return nil
+	case socks5UserPass:
+		if user == nil {
+			return errors.New("socks5 proxy requires authentication")
+		}
+	case socks5NoAcceptable:
+		return errors.New("socks5 proxy rejected authentication methods")
+	default:
+		return fmt.Errorf("unsupported socks5 auth method: %d", res[1])
+	}
+
+	name := user.Username()
+	pass, _ := user.Password()
+	if len(name) > 255 || len(pass) > 255 {
+		return errors.New("socks5 credentials too long")
+	}
+
+	req := []byte{0x01, byte(len(name))}
+	req = append(req, name...)
+	req = append(req, byte(len(pass)))
+	req = append(req, pass...)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	if _, err := io.ReadFull(conn, res); err != nil {
+		return err
+	}
+	if res[1] != 0x00 {
+		return errors.New("socks5 authentication failed")
+	}
+	return nil
+}
+
+func socks5Request(conn net.Conn, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port: %s", portStr)
+	}
+
+	req := []byte{socks5Version, socks5CmdConnect, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return errors.New("socks5 host name too long")
+		}
+		// let proxy resolve host name
+		req = append(req, socks5Domain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5IPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5IPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, 0, 0)
+	binary.BigEndian.PutUint16(req[len(req)-2:], uint16(port))
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	// reply: version, status, reserved, bound address type
+	res := make([]byte, 4)
+	if _, err := io.ReadFull(conn, res); err != nil {
+		return err
+	}
+	if res[1] != 0x00 {
+		return fmt.Errorf("socks5 connect %s failed with code: %d", addr, res[1])
+	}
+
+	var skip int
+	switch res[3] {
+	case socks5IPv4:
+		skip = net.IPv4len
+	case socks5IPv6:
+		skip = net.IPv6len
+	case socks5Domain:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(conn, l); err != nil {
+			return err
+		}
+		skip = int(l[0])
+	default:
+		return fmt.Errorf("unsupported socks5 address type: %d", res[3])
+	}
+
+	// bound address and port are not needed
+	_, err = io.ReadFull(conn, make([]byte, skip+2))
+	return err
+}
@@ -0,0 +1,191 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/inflight"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+)
+
+// ErrClosed is returned by requests waiting for acknowledgement when mux gets closed
+var ErrClosed = errors.New("mux is closed")
+
+// RequestError is returned along with notification rejecting the request
+type RequestError struct {
+	Notification *notification.Notification
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Notification.Type, e.Notification.Status, e.Notification.Text)
+}
+
+var fundingOfferTypes = map[string]bool{
+	"LIMIT":       true,
+	"FRRDELTAVAR": true,
+	"FRRDELTAFIX": true,
+}
+
+// SubmitOrder sends new order and waits for its acknowledgement until ctx is
+// done. Client order id is required to correlate acknowledgement
+func (m *Mux) SubmitOrder(ctx context.Context, o *order.NewRequest) (*notification.Notification, error) {
+	switch {
+	case o == nil:
+		return nil, errors.New("order is required")
+	case o.CID == 0:
+		return nil, errors.New("order cid is required")
+	case !strings.HasPrefix(o.Symbol, common.TradingPrefix):
+		return nil, fmt.Errorf("invalid trading pair symbol: %q", o.Symbol)
+	case o.Type == "":
+		return nil, errors.New("order type is required")
+	case o.Amount == 0:
+		return nil, errors.New("order amount is required")
+	case strings.Contains(o.Type, "LIMIT") && o.Price <= 0:
+		return nil, fmt.Errorf("%s order requires positive price", o.Type)
+	}
+	return m.request(ctx, o, inflight.Acks(inflight.Key(o)))
+}
+
+// UpdateOrder sends order update and waits for its acknowledgement until ctx is done
+func (m *Mux) UpdateOrder(ctx context.Context, o *order.UpdateRequest) (*notification.Notification, error) {
+	if o == nil || o.ID == 0 {
+		return nil, errors.New("order id is required")
+	}
+	return m.request(ctx, o, inflight.Acks(inflight.Key(o)))
+}
+
+// CancelOrder sends order cancel, by id or client id and its date, and waits
+// for its acknowledgement until ctx is done
+func (m *Mux) CancelOrder(ctx context.Context, o *order.CancelRequest) (*notification.Notification, error) {
+	switch {
+	case o == nil || (o.ID == 0 && o.CID == 0):
+		return nil, errors.New("order id or cid is required")
+	case o.ID == 0:
+		if err := validCIDDate(o.CIDDate); err != nil {
+			return nil, err
+		}
+	}
+	return m.request(ctx, o, inflight.Acks(inflight.Key(o)))
+}
+
+// CancelOrderMulti cancels several orders at once and waits for
+// acknowledgement until ctx is done. Concurrent requests are acknowledged
+// in order they were sent
+func (m *Mux) CancelOrderMulti(ctx context.Context, o *order.CancelMultiRequest) (*notification.Notification, error) {
+	if o == nil || (len(o.ID) == 0 && len(o.GID) == 0 && len(o.CID) == 0 && !o.All) {
+		return nil, errors.New("order ids, gids, cids or all are required")
+	}
+	for _, c := range o.CID {
+		if c.CID == 0 {
+			return nil, errors.New("order cid is required")
+		}
+		if err := validCIDDate(c.Date); err != nil {
+			return nil, err
+		}
+	}
+	return m.request(ctx, o, func(n *notification.Notification) bool {
+		return n.Type == "oc_multi-req"
+	})
+}
+
+// SubmitFundingOffer sends new funding offer and waits for its
+// acknowledgement until ctx is done. Offers are correlated by amount,
+// rate and period, as api acknowledges them without symbol
+func (m *Mux) SubmitFundingOffer(ctx context.Context, o *fundingoffer.SubmitRequest) (*notification.Notification, error) {
+	switch {
+	case o == nil:
+		return nil, errors.New("funding offer is required")
+	case !strings.HasPrefix(o.Symbol, common.FundingPrefix):
+		return nil, fmt.Errorf("invalid funding currency symbol: %q", o.Symbol)
+	case !fundingOfferTypes[o.Type]:
+		return nil, fmt.Errorf("invalid funding offer type: %q", o.Type)
+	case o.Amount == 0:
+		return nil, errors.New("funding offer amount is required")
+	case o.Rate < 0:
+		return nil, errors.New("funding offer rate can not be negative")
+	case o.Period < 2 || o.Period > 120:
+		return nil, fmt.Errorf("funding offer period has to be between 2 and 120 days, got: %d", o.Period)
+	}
+	return m.request(ctx, o, func(n *notification.Notification) bool {
+		v, ok := n.NotifyInfo.(fundingoffer.New)
+		return ok && n.Type == "fon-req" &&
+			v.Period == o.Period &&
+			(v.Amount == o.Amount || v.AmountOrig == o.Amount) &&
+			(v.Rate == o.Rate || o.Type != "LIMIT")
+	})
+}
+
+// CancelFundingOffer sends funding offer cancel and waits for its
+// acknowledgement until ctx is done
+func (m *Mux) CancelFundingOffer(ctx context.Context, o *fundingoffer.CancelRequest) (*notification.Notification, error) {
+	if o == nil || o.ID == 0 {
+		return nil, errors.New("funding offer id is required")
+	}
+	return m.request(ctx, o, inflight.Acks(inflight.Key(o)))
+}
+
+// CloseFundingLoan closes funding loan and waits for acknowledgement until ctx is done
+func (m *Mux) CloseFundingLoan(ctx context.Context, l *fundingloan.CancelRequest) (*notification.Notification, error) {
+	if l == nil || l.ID == 0 {
+		return nil, errors.New("funding loan id is required")
+	}
+	return m.request(ctx, l, inflight.Acks(inflight.Key(l)))
+}
+
+// CloseFundingCredit closes funding credit and waits for acknowledgement until ctx is done
+func (m *Mux) CloseFundingCredit(ctx context.Context, c *fundingcredit.CancelRequest) (*notification.Notification, error) {
+	if c == nil || c.ID == 0 {
+		return nil, errors.New("funding credit id is required")
+	}
+	return m.request(ctx, c, inflight.Acks(inflight.Key(c)))
+}
+
+// Calc asks api to recalculate balances. There is no acknowledgement, results
+// are passed to Listen callback as margin, funding, position and wallet updates
+func (m *Mux) Calc(ctx context.Context, c *calc.Request) error {
+	if c == nil || c.Empty() {
+		return errors.New("nothing to calculate")
+	}
+	return m.send(ctx, c)
+}
+
+// request sends payload and waits for notification accepted by match. Rejected
+// requests return notification along with RequestError
+func (m *Mux) request(ctx context.Context, pld interface{}, match func(*notification.Notification) bool) (*notification.Notification, error) {
+	ack, release := m.acks.Await(match)
+	defer release()
+
+	key := m.acks.Add(pld)
+	if err := m.send(ctx, pld); err != nil {
+		m.acks.Remove(key)
+		return nil, err
+	}
+
+	select {
+	case n := <-ack:
+		if n.Status == "ERROR" || n.Status == "FAILURE" {
+			return n, &RequestError{Notification: n}
+		}
+		return n, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-m.closeChan:
+		return nil, ErrClosed
+	}
+}
+
+func validCIDDate(date string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("order cid date has to be in 2006-01-02 format, got: %q", date)
+	}
+	return nil
+}
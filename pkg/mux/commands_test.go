@@ -0,0 +1,262 @@
+package mux_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawOrder returns order in api format with given ids and symbol
+func rawOrder(id, cid int64, symbol string) []interface{} {
+	o := make([]interface{}, 32)
+	o[0], o[2], o[3] = id, cid, symbol
+	return o
+}
+
+// rawOffer returns funding offer in api format, as acknowledged by api
+func rawOffer(id int64, amount, rate, period float64) []interface{} {
+	o := make([]interface{}, 21)
+	o[0], o[4], o[14], o[15] = id, amount, rate, period
+	return o
+}
+
+// respondCommands acknowledges authenticated requests with notifications.
+// Orders on tERRUSD are rejected, orders on tNONEUSD are never acknowledged
+func respondCommands(req []byte) []string {
+	if res := respondDefault(req); res != nil {
+		return res
+	}
+
+	var cmd []json.RawMessage
+	if err := json.Unmarshal(req, &cmd); err != nil || len(cmd) < 4 {
+		return nil
+	}
+
+	var op string
+	var pld struct {
+		ID     int64   `json:"id"`
+		CID    int64   `json:"cid"`
+		Symbol string  `json:"symbol"`
+		Amount float64 `json:"amount,string"`
+		Rate   float64 `json:"rate,string"`
+		Period float64 `json:"period"`
+	}
+	_ = json.Unmarshal(cmd[1], &op)
+	_ = json.Unmarshal(cmd[3], &pld)
+
+	status := "SUCCESS"
+	var info interface{}
+	switch op {
+	case "on":
+		if pld.Symbol == "tNONEUSD" {
+			return nil
+		}
+		if pld.Symbol == "tERRUSD" {
+			status = "ERROR"
+		}
+		info = rawOrder(pld.CID+1000, pld.CID, pld.Symbol)
+	case "ou", "oc":
+		info = rawOrder(pld.ID, pld.CID, "tBTCUSD")
+	case "oc_multi":
+		info = []interface{}{}
+	case "fon":
+		info = rawOffer(1, pld.Amount, pld.Rate, pld.Period)
+	case "foc", "flc", "fcc":
+		info = rawOffer(pld.ID, 0, 0, 0)
+	default:
+		return nil
+	}
+
+	n, _ := json.Marshal([]interface{}{0, "n", []interface{}{1, op + "-req", nil, nil, info, nil, status, op + " " + status}})
+	return []string{string(n)}
+}
+
+func TestCommandValidation(t *testing.T) {
+	ctx := context.Background()
+	cases := map[string]func(m *mux.Mux) error{
+		"nil order": func(m *mux.Mux) error {
+			_, err := m.SubmitOrder(ctx, nil)
+			return err
+		},
+		"order without cid": func(m *mux.Mux) error {
+			_, err := m.SubmitOrder(ctx, &order.NewRequest{Symbol: "tBTCUSD", Type: "MARKET", Amount: 1})
+			return err
+		},
+		"order with funding symbol": func(m *mux.Mux) error {
+			_, err := m.SubmitOrder(ctx, &order.NewRequest{CID: 1, Symbol: "fUSD", Type: "MARKET", Amount: 1})
+			return err
+		},
+		"order without amount": func(m *mux.Mux) error {
+			_, err := m.SubmitOrder(ctx, &order.NewRequest{CID: 1, Symbol: "tBTCUSD", Type: "MARKET"})
+			return err
+		},
+		"limit order without price": func(m *mux.Mux) error {
+			_, err := m.SubmitOrder(ctx, &order.NewRequest{CID: 1, Symbol: "tBTCUSD", Type: "EXCHANGE LIMIT", Amount: 1})
+			return err
+		},
+		"update without id": func(m *mux.Mux) error {
+			_, err := m.UpdateOrder(ctx, &order.UpdateRequest{Price: 1})
+			return err
+		},
+		"cancel without ids": func(m *mux.Mux) error {
+			_, err := m.CancelOrder(ctx, &order.CancelRequest{})
+			return err
+		},
+		"cancel by cid without date": func(m *mux.Mux) error {
+			_, err := m.CancelOrder(ctx, &order.CancelRequest{CID: 1, CIDDate: "10/10/2020"})
+			return err
+		},
+		"empty cancel multi": func(m *mux.Mux) error {
+			_, err := m.CancelOrderMulti(ctx, &order.CancelMultiRequest{})
+			return err
+		},
+		"cancel multi with invalid cid": func(m *mux.Mux) error {
+			_, err := m.CancelOrderMulti(ctx, &order.CancelMultiRequest{CID: []order.ClientID{{CID: 1}}})
+			return err
+		},
+		"offer with trading symbol": func(m *mux.Mux) error {
+			_, err := m.SubmitFundingOffer(ctx, &fundingoffer.SubmitRequest{Type: "LIMIT", Symbol: "tBTCUSD", Amount: 100, Rate: 0.001, Period: 2})
+			return err
+		},
+		"offer with unknown type": func(m *mux.Mux) error {
+			_, err := m.SubmitFundingOffer(ctx, &fundingoffer.SubmitRequest{Type: "MARKET", Symbol: "fUSD", Amount: 100, Rate: 0.001, Period: 2})
+			return err
+		},
+		"offer with invalid period": func(m *mux.Mux) error {
+			_, err := m.SubmitFundingOffer(ctx, &fundingoffer.SubmitRequest{Type: "LIMIT", Symbol: "fUSD", Amount: 100, Rate: 0.001, Period: 121})
+			return err
+		},
+		"offer cancel without id": func(m *mux.Mux) error {
+			_, err := m.CancelFundingOffer(ctx, &fundingoffer.CancelRequest{})
+			return err
+		},
+		"loan close without id": func(m *mux.Mux) error {
+			_, err := m.CloseFundingLoan(ctx, &fundingloan.CancelRequest{})
+			return err
+		},
+		"credit close without id": func(m *mux.Mux) error {
+			_, err := m.CloseFundingCredit(ctx, nil)
+			return err
+		},
+		"empty calc": func(m *mux.Mux) error {
+			return m.Calc(ctx, &calc.Request{})
+		},
+	}
+
+	for k, call := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := newMux()
+			err := call(m)
+			require.NotNil(t, err)
+			assert.NotEqual(t, mux.ErrNotAuthorized, err)
+			assert.Equal(t, 0, m.PendingAcks())
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	pub, pubURL := newTestServer(t, nil)
+	defer pub.Close()
+	auth, authURL := newTestServer(t, respondCommands)
+	defer auth.Close()
+
+	m, _ := startPrivateMux(t, pubURL, authURL)
+	defer m.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.Run("submit order", func(t *testing.T) {
+		n, err := m.SubmitOrder(ctx, &order.NewRequest{CID: 7, Symbol: "tBTCUSD", Type: "EXCHANGE LIMIT", Amount: 1, Price: 7000})
+		require.Nil(t, err)
+		assert.Equal(t, "on-req", n.Type)
+		assert.Equal(t, int64(1007), n.NotifyInfo.(order.New).ID)
+	})
+
+	t.Run("rejected order", func(t *testing.T) {
+		n, err := m.SubmitOrder(ctx, &order.NewRequest{CID: 8, Symbol: "tERRUSD", Type: "MARKET", Amount: 1})
+		require.NotNil(t, err)
+		require.IsType(t, &mux.RequestError{}, err)
+		assert.Equal(t, n, err.(*mux.RequestError).Notification)
+		assert.Equal(t, "ERROR", n.Status)
+	})
+
+	t.Run("correlates concurrent orders", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := int64(100); i < 110; i++ {
+			wg.Add(1)
+			go func(cid int64) {
+				defer wg.Done()
+				n, err := m.SubmitOrder(ctx, &order.NewRequest{CID: cid, Symbol: "tBTCUSD", Type: "MARKET", Amount: 1})
+				if assert.Nil(t, err) {
+					assert.Equal(t, cid, n.NotifyInfo.(order.New).CID)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	t.Run("update and cancel order", func(t *testing.T) {
+		n, err := m.UpdateOrder(ctx, &order.UpdateRequest{ID: 1007, Price: 7100})
+		require.Nil(t, err)
+		assert.Equal(t, int64(1007), n.NotifyInfo.(order.Update).ID)
+
+		n, err = m.CancelOrder(ctx, &order.CancelRequest{CID: 7, CIDDate: "2020-10-10"})
+		require.Nil(t, err)
+		assert.Equal(t, int64(7), n.NotifyInfo.(order.Cancel).CID)
+
+		n, err = m.CancelOrderMulti(ctx, &order.CancelMultiRequest{All: true})
+		require.Nil(t, err)
+		assert.Equal(t, "oc_multi-req", n.Type)
+	})
+
+	t.Run("funding", func(t *testing.T) {
+		n, err := m.SubmitFundingOffer(ctx, &fundingoffer.SubmitRequest{Type: "LIMIT", Symbol: "fUSD", Amount: 100, Rate: 0.001, Period: 2})
+		require.Nil(t, err)
+		assert.Equal(t, 100.0, n.NotifyInfo.(fundingoffer.New).Amount)
+
+		n, err = m.CancelFundingOffer(ctx, &fundingoffer.CancelRequest{ID: 1})
+		require.Nil(t, err)
+		assert.Equal(t, "foc-req", n.Type)
+
+		n, err = m.CloseFundingLoan(ctx, &fundingloan.CancelRequest{ID: 2})
+		require.Nil(t, err)
+		assert.Equal(t, "flc-req", n.Type)
+
+		n, err = m.CloseFundingCredit(ctx, &fundingcredit.CancelRequest{ID: 3})
+		require.Nil(t, err)
+		assert.Equal(t, "fcc-req", n.Type)
+	})
+
+	t.Run("calc", func(t *testing.T) {
+		require.Nil(t, m.Calc(ctx, &calc.Request{MarginBase: true}))
+		eventually(t, func() bool { return auth.count(`[0,"calc",null,[["margin_base"]]]`) == 1 }, "expected calc request")
+	})
+
+	t.Run("waits until ctx is done", func(t *testing.T) {
+		short, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		_, err := m.SubmitOrder(short, &order.NewRequest{CID: 9, Symbol: "tNONEUSD", Type: "MARKET", Amount: 1})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("close releases waiting requests", func(t *testing.T) {
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			m.Close()
+		}()
+		_, err := m.SubmitOrder(context.Background(), &order.NewRequest{CID: 10, Symbol: "tNONEUSD", Type: "MARKET", Amount: 1})
+		assert.Equal(t, mux.ErrClosed, err)
+	})
+}
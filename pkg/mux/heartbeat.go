@@ -0,0 +1,224 @@
+package mux
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+)
+
+// StaleChannel is passed to Listen callback when channel has not received
+// any message, heartbeats included, within heartbeat timeout
+type StaleChannel struct {
+	Subscription
+	LastSeen time.Time
+	// Reconnect is set if all channels of the connection went stale, so the
+	// connection got replaced instead of channel being resubscribed
+	Reconnect bool
+}
+
+// WithHeartbeatTimeout sets how long a channel can stay silent before it is
+// considered stale. Api sends heartbeats every 15 seconds on quiet channels.
+// Stale channels get resubscribed, connections with all channels stale get
+// replaced. Zero disables the check
+func (m *Mux) WithHeartbeatTimeout(timeout time.Duration) *Mux {
+	m.hbTimeout = timeout
+	return m
+}
+
+// touch records that channel has received a message
+func (m *Mux) touch(cid int, chID int64) {
+	if m.hbTimeout <= 0 {
+		return
+	}
+
+	m.seenMtx.Lock()
+	defer m.seenMtx.Unlock()
+	m.seen[chanKey{cid, chID}] = time.Now()
+}
+
+// setSeen must be called with mtx held
+func (m *Mux) setSeen(k chanKey, t time.Time) {
+	m.seenMtx.Lock()
+	defer m.seenMtx.Unlock()
+	m.seen[k] = t
+}
+
+// forgetSeen must be called with mtx held
+func (m *Mux) forgetSeen(cid int, chID int64) {
+	m.seenMtx.Lock()
+	defer m.seenMtx.Unlock()
+	delete(m.seen, chanKey{cid, chID})
+}
+
+// forgetClientSeen must be called with mtx held
+func (m *Mux) forgetClientSeen(cid int) {
+	m.seenMtx.Lock()
+	defer m.seenMtx.Unlock()
+	for k := range m.seen {
+		if k.cid == cid {
+			delete(m.seen, k)
+		}
+	}
+}
+
+// watchHeartbeats checks channels for staleness until mux is closed
+func (m *Mux) watchHeartbeats() {
+	if m.hbTimeout <= 0 {
+		return
+	}
+
+	interval := m.hbTimeout / 4
+	if interval <= 0 {
+		interval = m.hbTimeout
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				m.sweep(now)
+			case <-m.closeChan:
+				return
+			}
+		}
+	}()
+}
+
+// sweep resubscribes stale channels, replaces stale clients and reports
+// both to Listen callback
+func (m *Mux) sweep(now time.Time) {
+	stale, reconnect, private := m.findStale(now)
+
+	for _, s := range stale {
+		log.Printf("stale channel: %d on cid: %d, last seen: %s\n", s.ChanID, s.CID, s.LastSeen)
+		if !s.Reconnect {
+			m.unsubscribeStale(s.CID, s.ChanID)
+		}
+		m.notify(s)
+	}
+
+	for cid := range reconnect {
+		if err := m.resetPublicClient(cid); err != nil {
+			m.notify(fmt.Errorf("conn:%d reconnect failed | err:%s", cid, err))
+		}
+	}
+
+	if private != nil {
+		m.notify(*private)
+		if lost, ok := m.privateFailed(fmt.Errorf("heartbeat timeout, last seen: %s", private.LastSeen)); ok {
+			m.notify(lost)
+		}
+	}
+}
+
+// findStale returns stale channels, marking ones on otherwise healthy clients
+// for resubscription, set of clients to replace and stale private channel
+func (m *Mux) findStale(now time.Time) (stale []StaleChannel, reconnect map[int]bool, private *StaleChannel) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.closed() {
+		return
+	}
+
+	reconnect = make(map[int]bool)
+	active := make(map[int]int)
+	staleSubs := make(map[int][]*Subscription)
+	for _, s := range m.subs {
+		seen := m.lastSeen(chanKey{s.CID, s.ChanID})
+		expired := now.Sub(seen) > m.hbTimeout
+
+		switch {
+		case s.State == SubscriptionActive:
+			active[s.CID]++
+			if expired {
+				staleSubs[s.CID] = append(staleSubs[s.CID], s)
+			}
+		case s.State == SubscriptionUnsubscribing && s.resubscribe && expired:
+			// unsubscribe of stale channel was not confirmed either
+			reconnect[s.CID] = true
+		}
+	}
+
+	for cid, subs := range staleSubs {
+		if len(subs) == active[cid] {
+			reconnect[cid] = true
+		}
+
+		for _, s := range subs {
+			stale = append(stale, StaleChannel{
+				Subscription: *s,
+				LastSeen:     m.lastSeen(chanKey{s.CID, s.ChanID}),
+				Reconnect:    reconnect[cid],
+			})
+			if !reconnect[cid] {
+				m.resubscribe(s, now)
+			}
+		}
+	}
+
+	if m.authenticated && m.privateClient != nil {
+		if seen := m.lastSeen(chanKey{0, 0}); now.Sub(seen) > m.hbTimeout {
+			private = &StaleChannel{
+				Subscription: Subscription{
+					Subscribe: event.Subscribe{Event: "auth"},
+					State:     SubscriptionActive,
+				},
+				LastSeen:  seen,
+				Reconnect: true,
+			}
+		}
+	}
+	return
+}
+
+// lastSeen must be called with mtx held
+func (m *Mux) lastSeen(k chanKey) time.Time {
+	m.seenMtx.Lock()
+	defer m.seenMtx.Unlock()
+	return m.seen[k]
+}
+
+// resubscribe marks stale channel to be unsubscribed, subscription is sent
+// again once api confirms it. Must be called with mtx held
+func (m *Mux) resubscribe(s *Subscription, now time.Time) {
+	c, ok := m.publicClients[s.CID]
+	if !ok {
+		return
+	}
+
+	c.RemoveSub(s.Subscribe)
+	s.State = SubscriptionUnsubscribing
+	s.resubscribe = true
+	// unsubscribe has to be confirmed within timeout as well
+	m.setSeen(chanKey{s.CID, s.ChanID}, now)
+}
+
+// unsubscribeStale sends unsubscribe request for channel marked by
+// resubscribe. It is sent without mtx held, so slow connection does not
+// block the rest of mux
+func (m *Mux) unsubscribeStale(cid int, chID int64) {
+	m.mtx.RLock()
+	c, ok := m.publicClients[cid]
+	m.mtx.RUnlock()
+	if !ok {
+		return
+	}
+
+	if err := c.Unsubscribe(chID); err != nil {
+		log.Printf("failed unsubscribing stale chanId %d on cid %d: %s\n", chID, cid, err)
+	}
+}
+
+// notify passes message to Listen callback, unless mux gets closed first
+func (m *Mux) notify(ms interface{}) {
+	select {
+	case m.notices <- ms:
+	case <-m.closeChan:
+	}
+}
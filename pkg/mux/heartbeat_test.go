@@ -0,0 +1,191 @@
+package mux_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenStale starts listening and passes stale channel and auth
+// lifecycle messages to returned channel
+func listenStale(t *testing.T, m *mux.Mux) chan interface{} {
+	received := make(chan interface{}, 100)
+	go func() {
+		assert.Nil(t, m.Listen(func(ms interface{}, err error) {
+			switch ms.(type) {
+			case mux.StaleChannel, mux.AuthLost, mux.AuthRestored:
+				select {
+				case received <- ms:
+				default:
+				}
+			}
+		}))
+	}()
+	eventually(t, m.IsConnected, "mux did not start listening")
+	return received
+}
+
+// heartbeat pushes heartbeats of active subscriptions accepted by filter
+// until returned func is called
+func heartbeat(srv *testServer, m *mux.Mux, filter func(mux.Subscription) bool) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Millisecond * 20)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, s := range m.Subscriptions() {
+					if s.State == mux.SubscriptionActive && filter(s) {
+						srv.push(fmt.Sprintf(`[%d,"hb"]`, s.ChanID))
+					}
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func nextStale(t *testing.T, received chan interface{}) mux.StaleChannel {
+	for {
+		if s, ok := nextReceived(t, received).(mux.StaleChannel); ok {
+			return s
+		}
+	}
+}
+
+func TestStaleChannel(t *testing.T) {
+	t.Run("resubscribes stale channel", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := newMux().WithPublicURL(url).WithHeartbeatTimeout(time.Millisecond * 100).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+		received := listenStale(t, m)
+
+		btc := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"}
+		eth := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tETHUSD"}
+		m.Subscribe(btc).Subscribe(eth)
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 2 }, "expected active subscriptions")
+
+		// eth channel freezes until reported stale
+		var frozen int32 = 1
+		stop := heartbeat(srv, m, func(s mux.Subscription) bool {
+			return s.Subscribe == btc || atomic.LoadInt32(&frozen) == 0
+		})
+		defer stop()
+
+		stale := nextStale(t, received)
+		atomic.StoreInt32(&frozen, 0)
+		assert.Equal(t, eth, stale.Subscribe)
+		assert.False(t, stale.Reconnect)
+		assert.False(t, stale.LastSeen.IsZero())
+
+		// channel is unsubscribed and subscribed again on the same connection
+		eventually(t, func() bool {
+			subs := subsInState(m, mux.SubscriptionActive)
+			return len(subs) == 2 && subs[1].Subscribe == eth && subs[1].ChanID != stale.ChanID
+		}, "expected stale channel to be resubscribed")
+		assert.Equal(t, 1, srv.count(fmt.Sprintf(`{"event":"unsubscribe","chanId":%d}`, stale.ChanID)))
+		assert.Equal(t, 2, srv.count(`"symbol":"tETHUSD"`))
+		assert.Equal(t, 1, srv.count(`"symbol":"tBTCUSD"`))
+		assert.Equal(t, 1, srv.connCount())
+	})
+
+	t.Run("replaces connection with all channels stale", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := newMux().WithPublicURL(url).WithHeartbeatTimeout(time.Millisecond * 100).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+		received := listenStale(t, m)
+
+		sub := event.Subscribe{Event: "subscribe", Channel: "trades", Symbol: "tBTCUSD"}
+		m.Subscribe(sub)
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 1 }, "expected active subscription")
+
+		stale := nextStale(t, received)
+		assert.Equal(t, sub, stale.Subscribe)
+		assert.Equal(t, 1, stale.CID)
+		assert.True(t, stale.Reconnect)
+
+		eventually(t, func() bool { return srv.connCount() == 2 }, "expected stale connection to be replaced")
+		eventually(t, func() bool {
+			subs := subsInState(m, mux.SubscriptionActive)
+			return len(subs) == 1 && subs[0].CID == 2
+		}, "expected resubscription on new connection")
+		assert.Equal(t, 0, srv.count(`"event":"unsubscribe"`))
+	})
+
+	t.Run("reconnects stale private connection", func(t *testing.T) {
+		pub, pubURL := newTestServer(t, nil)
+		defer pub.Close()
+		auth, authURL := newTestServer(t, nil)
+		defer auth.Close()
+
+		m := newMux().
+			WithPublicURL(pubURL).
+			WithAuthURL(authURL).
+			WithAPIKEY("key").
+			WithAPISEC("sec").
+			WithHeartbeatTimeout(time.Millisecond * 100).
+			TransformRaw().
+			Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+		received := listenStale(t, m)
+
+		stale := nextStale(t, received)
+		assert.Equal(t, "auth", stale.Event)
+		assert.True(t, stale.Reconnect)
+
+		_, ok := nextReceived(t, received).(mux.AuthLost)
+		require.True(t, ok)
+		_, ok = nextReceived(t, received).(mux.AuthRestored)
+		require.True(t, ok)
+		assert.Equal(t, 2, auth.connCount())
+	})
+
+	t.Run("heartbeats keep channels alive", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := newMux().WithPublicURL(url).WithHeartbeatTimeout(time.Millisecond * 100).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+		received := listenStale(t, m)
+
+		stop := heartbeat(srv, m, func(mux.Subscription) bool { return true })
+		defer stop()
+
+		m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"})
+		time.Sleep(time.Millisecond * 300)
+		assert.Len(t, received, 0)
+		assert.Equal(t, 1, srv.connCount())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := newMux().WithPublicURL(url).WithHeartbeatTimeout(0).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+		received := listenStale(t, m)
+
+		m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"})
+		time.Sleep(time.Millisecond * 200)
+		assert.Len(t, received, 0)
+		assert.Equal(t, 1, srv.connCount())
+	})
+}
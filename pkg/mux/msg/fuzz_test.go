@@ -0,0 +1,67 @@
+//go:build go1.18
+// +build go1.18
+
+package msg_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
+)
+
+func FuzzProcessPublic(f *testing.F) {
+	for _, seed := range []struct{ channel, symbol, key, data string }{
+		{"ticker", "tBTCUSD", "", `[]`},
+		{"ticker", "tBTCUSD", "", `[1,"hb"]`},
+		{"ticker", "tBTCUSD", "", `[1,[7616.5,31.8,7617.5,43.3,-550.8,-0.0674,7617.1,8314.7,8257.8,7500]]`},
+		{"ticker", "fUSD", "", `[1,[[0.0003,0.0002,30,1000,0.00025,2,2000,0.0001,0.1,0.0002,100000,0.0004,0.0001,null,null,3000]]]`},
+		{"trades", "tBTCUSD", "", `[1,"te",[401597395,1574694478808,0.005,7245.3]]`},
+		{"trades", "fUSD", "", `[1,[[133323543,1574694605000,-59.84,0.00023647,2]]]`},
+		{"book", "tBTCUSD", "", `[1,[[7254.7,3,0.3],[7254.8,1,-0.1]]]`},
+		{"book", "tBTCUSD", "", `[1,"cs",-1471812406]`},
+		{"candles", "", "trade:1m:tBTCUSD", `[1,[[1576508040000,7245.6,7245.5,7245.6,7245.5,0.3]]]`},
+		{"status", "", "liq:global", `[1,[["pos",145400868,1609144352338,null,"tBTCF0:USTF0",-0.024,28216,null,1,1,null,28301]]]`},
+		{"status", "", "deriv", `[1,[1596124822000]]`},
+	} {
+		f.Add(seed.channel, seed.symbol, seed.key, seed.data)
+	}
+	f.Fuzz(func(t *testing.T, channel, symbol, key, data string) {
+		m := msg.Msg{Data: []byte(data), IsPublic: true}
+		raw, pld, chID, _, err := m.PreprocessRaw()
+		if err != nil {
+			return
+		}
+
+		inf := event.Info{Subscribe: event.Subscribe{Channel: channel, Symbol: symbol, Key: key, Precision: "P0"}}
+		_, _ = m.ProcessPublic(raw, pld, chID, inf)
+	})
+}
+
+func FuzzProcessPrivate(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[0]`,
+		`[0,"hb"]`,
+		`[0,"bu",[4131.85,4131.85]]`,
+		`[0,"miu",["base",[-13.01,0,49331.7,49318.6,27]]]`,
+		`[0,"miu",["sym","tETHUSD",[149361.09,149639.26,830.01,895.06]]]`,
+		`[0,"ws",[["exchange","UST",19788.65,0,19788.65,null,null]]]`,
+		`[0,"te",[402088407,"tETHUST",1574963975602,34938060782,-0.2,153.57,"MARKET",0,-1,null,null,0]]`,
+		`[0,"n",[1575289447641,"on-req",null,null,[33950998275,null,1573476747887,"tETHUSD",1573476748000,1573476748000,-0.5,-0.5,"LIMIT",null,null,null,0,"ACTIVE",null,null,220,0,0,0,null,null,null,0,0,null,null,null,"BFX",null,null,null],null,"SUCCESS","Submitting"]]`,
+		`[0,"n",[1575289447641,"fon-req",null,null,{},null,"SUCCESS",""]]`,
+		`[0,"fos",[[652606505,"fETH",1574000611000,1574000611000,0.29,0.29,"LIMIT",null,null,0,"ACTIVE",null,null,null,0.0002,2,0,null,null,0,null]]]`,
+		`[0,"hfts",[[636040,"fUST",1575451982000,41237922,-1000,0.002,7,null]]]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		m := msg.Msg{Data: []byte(data)}
+		raw, pld, chID, msgType, err := m.PreprocessRaw()
+		if err != nil {
+			return
+		}
+
+		_, _ = m.ProcessPrivate(raw, pld, chID, msgType)
+	})
+}
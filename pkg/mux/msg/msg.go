@@ -43,12 +43,56 @@ func (m Msg) IsRaw() bool {
 	return bytes.HasPrefix(t, []byte("["))
 }
 
+// ChanID reads channel id of raw message without decoding the rest of it.
+// Returns false if message is not raw or does not start with channel id
+func (m Msg) ChanID() (int64, bool) {
+	t := bytes.TrimLeftFunc(m.Data, unicode.IsSpace)
+	if !bytes.HasPrefix(t, []byte("[")) {
+		return 0, false
+	}
+	t = bytes.TrimLeftFunc(t[1:], unicode.IsSpace)
+
+	var chID int64
+	n := 0
+	for ; n < len(t) && t[n] >= '0' && t[n] <= '9'; n++ {
+		chID = chID*10 + int64(t[n]-'0')
+	}
+	return chID, n > 0
+}
+
+// Type reads type of raw message, like "te" or "hb", without decoding the
+// rest of it. Returns empty string for snapshots and updates without type
+func (m Msg) Type() string {
+	t := bytes.TrimLeftFunc(m.Data, unicode.IsSpace)
+	i := bytes.IndexByte(t, ',')
+	if !bytes.HasPrefix(t, []byte("[")) || i < 0 {
+		return ""
+	}
+
+	t = bytes.TrimLeftFunc(t[i+1:], unicode.IsSpace)
+	if !bytes.HasPrefix(t, []byte(`"`)) {
+		return ""
+	}
+
+	end := bytes.IndexByte(t[1:], '"')
+	if end < 0 {
+		return ""
+	}
+	return string(t[1 : end+1])
+}
+
 // PreprocessRaw takes raw slice of bytes and splits it into:
 // 1. raw payload data - always last element of the slice
 // 2. chanID - always 1st element of the slice
 // 3. msg type - in 3 element msg slice, type is always at index 1
+// Returns error if message is not a json array of at least 2 elements
 func (m Msg) PreprocessRaw() (raw []interface{}, pld interface{}, chID int64, msgType string, err error) {
-	err = json.Unmarshal(m.Data, &raw)
+	if err = json.Unmarshal(m.Data, &raw); err != nil {
+		return nil, nil, 0, "", fmt.Errorf("parsing msg: %s, err: %s", m.Data, err)
+	}
+	if len(raw) < 2 {
+		return nil, nil, 0, "", fmt.Errorf("unexpected msg: %s", m.Data)
+	}
 	pld = raw[len(raw)-1]
 	chID = convert.I64ValOrZero(raw[0])
 	if len(raw) == 3 {
@@ -77,6 +77,107 @@ func TestIsRaw(t *testing.T) {
 	}
 }
 
+func TestChanID(t *testing.T) {
+	cases := map[string]struct {
+		pld      []byte
+		expected int64
+		ok       bool
+	}{
+		"heartbeat": {
+			pld:      []byte(`[17082,"hb"]`),
+			expected: 17082,
+			ok:       true,
+		},
+		"with whitespace": {
+			pld:      []byte(` [ 42, [1,2,3]]`),
+			expected: 42,
+			ok:       true,
+		},
+		"private channel": {
+			pld:      []byte(`[0,"n",[]]`),
+			expected: 0,
+			ok:       true,
+		},
+		"event": {
+			pld: []byte(`{"event":"info"}`),
+		},
+		"no channel id": {
+			pld: []byte(`["hb"]`),
+		},
+		"empty": {
+			pld: []byte(``),
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := msg.Msg{
+				Data: v.pld,
+			}
+
+			got, ok := m.ChanID()
+			assert.Equal(t, v.ok, ok)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	cases := map[string]struct {
+		pld      []byte
+		expected string
+	}{
+		"heartbeat": {
+			pld:      []byte(`[17082,"hb"]`),
+			expected: "hb",
+		},
+		"private message": {
+			pld:      []byte(`[0, "te", [1,"tBTCUSD"]]`),
+			expected: "te",
+		},
+		"snapshot": {
+			pld: []byte(`[17082,[[1,2,3]]]`),
+		},
+		"event": {
+			pld: []byte(`{"event":"info"}`),
+		},
+		"unterminated": {
+			pld: []byte(`[0,"te`),
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := msg.Msg{
+				Data: v.pld,
+			}
+
+			assert.Equal(t, v.expected, m.Type())
+		})
+	}
+}
+
+func TestPreprocessRaw(t *testing.T) {
+	cases := map[string]struct {
+		pld []byte
+	}{
+		"invalid json": {pld: []byte(`[1,`)},
+		"not an array": {pld: []byte(`{"event":"info"}`)},
+		"empty array":  {pld: []byte(`[]`)},
+		"channel only": {pld: []byte(`[1]`)},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := msg.Msg{Data: v.pld}
+			raw, pld, _, _, err := m.PreprocessRaw()
+			assert.Error(t, err)
+			assert.Nil(t, raw)
+			assert.Nil(t, pld)
+		})
+	}
+}
+
 func TestProcessEvent(t *testing.T) {
 	m := msg.Msg{
 		Data: []byte(`{
@@ -1,60 +1,133 @@
 package mux
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/inflight"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/ratelimit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/utils"
 )
 
 // Mux will manage all connections and subscriptions. Will check if subscriptions
 // limit is reached and spawn new connection when that happens. It will also listen
 // to all incomming client messages and reconnect client with all its subscriptions
-// in case of a failure
+// in case of a failure.
+//
+// Configuration methods (With*, TransformRaw) are meant to be called before Start.
+// Everything else is safe for concurrent use
 type Mux struct {
-	cid                int
-	dms                int
-	publicChan         chan msg.Msg
-	publicClients      map[int]*client.Client
-	privateChan        chan msg.Msg
-	closeChan          chan bool
-	privateClient      *client.Client
-	mtx                *sync.RWMutex
-	Err                error
-	transform          bool
-	apikey             string
-	apisec             string
-	subInfo            map[int64]event.Info
-	authenticated      bool
-	publicURL          string
-	authURL            string
-	online             bool
-	rateLimitQueueSize int
+	publicChan      chan msg.Msg
+	privateChan     chan msg.Msg
+	closeChan       chan struct{}
+	closeOnce       sync.Once
+	startOnce       sync.Once
+	subTokens       chan struct{}
+	transform       bool
+	dms             int
+	apikey          string
+	apisec          string
+	authFilter      []string
+	publicURL       string
+	authURL         string
+	orderGovernor   *ratelimit.Governor
+	acks            *inflight.Tracker
+	shutdownTimeout time.Duration
+	subsLimit       int
+	sharding        Sharding
+	conns           int
+	pins            map[event.Subscribe]string
+	reconnectMin    time.Duration
+	reconnectMax    time.Duration
+	nonceGen        utils.NonceGenerator
+	dialer          client.Dialer
+	hbTimeout       time.Duration
+	notices         chan interface{}
+	manageBooks     bool
+
+	// dialMtx serializes dials of public clients, so that concurrent
+	// subscriptions do not open more connections than needed. It is taken
+	// before mtx, which is released while dialing
+	dialMtx sync.Mutex
+
+	// mtx guards all fields below, including Err writes
+	mtx               *sync.RWMutex
+	lastCID           int
+	publicClients     map[int]*client.Client
+	shards            map[int]string
+	current           map[string]int
+	privateClient     *client.Client
+	subInfo           map[chanKey]event.Info
+	subs              map[event.Subscribe]*Subscription
+	authenticated     bool
+	reconnecting      bool
+	reconnectAttempts int
+	online            bool
+	Err               error
+
+	// seenMtx guards seen, it is taken after mtx when both are needed
+	seenMtx sync.Mutex
+	seen    map[chanKey]time.Time
+
+	// booksMtx guards books, it is taken after mtx when both are needed
+	booksMtx sync.RWMutex
+	books    map[chanKey]*managedBook
+
+	// routesMtx guards routes, it is never held while taking other locks
+	routesMtx sync.RWMutex
+	routes    []*Route
 }
 
 // api rate limit is 20 calls per minute. 1x3s, 20x1min
 const (
 	rateLimitDuration     = 3 * time.Second
 	maxRateLimitQueueSize = 20
+	maxSubsPerConn        = 30
 )
 
 // New returns pointer to instance of mux
 func New() *Mux {
-	return &Mux{
-		publicChan:    make(chan msg.Msg),
-		privateChan:   make(chan msg.Msg),
-		closeChan:     make(chan bool),
-		publicClients: make(map[int]*client.Client),
-		mtx:           &sync.RWMutex{},
-		subInfo:       map[int64]event.Info{},
-		publicURL:     "wss://api-pub.bitfinex.com/ws/2",
-		authURL:       "wss://api.bitfinex.com/ws/2",
+	m := &Mux{
+		publicChan:      make(chan msg.Msg),
+		privateChan:     make(chan msg.Msg),
+		closeChan:       make(chan struct{}),
+		subTokens:       make(chan struct{}, maxRateLimitQueueSize),
+		publicClients:   make(map[int]*client.Client),
+		shards:          make(map[int]string),
+		current:         make(map[string]int),
+		pins:            make(map[event.Subscribe]string),
+		conns:           2,
+		mtx:             &sync.RWMutex{},
+		subInfo:         map[chanKey]event.Info{},
+		subs:            map[event.Subscribe]*Subscription{},
+		publicURL:       "wss://api-pub.bitfinex.com/ws/2",
+		authURL:         "wss://api.bitfinex.com/ws/2",
+		acks:            inflight.New(),
+		shutdownTimeout: 5 * time.Second,
+		subsLimit:       maxSubsPerConn,
+		reconnectMin:    defaultReconnectMin,
+		reconnectMax:    defaultReconnectMax,
+		nonceGen:        utils.NewEpochNonceGenerator(),
+		hbTimeout:       30 * time.Second,
+		notices:         make(chan interface{}, 16),
+		seen:            map[chanKey]time.Time{},
+		books:           map[chanKey]*managedBook{},
 	}
+
+	// allow initial burst of subscriptions
+	for i := 0; i < maxRateLimitQueueSize; i++ {
+		m.subTokens <- struct{}{}
+	}
+	return m
 }
 
 // TransformRaw enables data transformation and mapping to appropriate
@@ -82,6 +155,13 @@ func (m *Mux) WithAPISEC(sec string) *Mux {
 	return m
 }
 
+// WithAuthFilter accepts and persists filters applied to authenticated
+// channel. Filters are re-applied each time private client reconnects
+func (m *Mux) WithAuthFilter(filters []string) *Mux {
+	m.authFilter = filters
+	return m
+}
+
 // WithPublicURL accepts and persists public api url
 func (m *Mux) WithPublicURL(url string) *Mux {
 	m.publicURL = url
@@ -94,56 +174,238 @@ func (m *Mux) WithAuthURL(url string) *Mux {
 	return m
 }
 
+// WithDialer sets dialer used by public and private clients to connect to api.
+// Allows proxies (see client.NewProxyDialer), custom TLS or in-memory transport
+func (m *Mux) WithDialer(d client.Dialer) *Mux {
+	m.dialer = d
+	return m
+}
+
+// WithOrderRateLimit limits authenticated input sent via Send to limit
+// messages per interval. Excess messages are queued: cancels go first,
+// successive updates of the same order are coalesced, new orders go last
+func (m *Mux) WithOrderRateLimit(limit int, interval time.Duration) *Mux {
+	if m.orderGovernor != nil {
+		m.orderGovernor.Close()
+	}
+	m.orderGovernor = ratelimit.New(limit, interval)
+	return m
+}
+
+// WithShutdownTimeout sets how long Run waits for acknowledgements of
+// in-flight authenticated requests before closing connections
+func (m *Mux) WithShutdownTimeout(timeout time.Duration) *Mux {
+	m.shutdownTimeout = timeout
+	return m
+}
+
+// OrderQueueStats returns depth and wait times of authenticated input queue.
+// Zero value is returned if order rate limit is not set
+func (m *Mux) OrderQueueStats() ratelimit.Stats {
+	if m.orderGovernor == nil {
+		return ratelimit.Stats{}
+	}
+	return m.orderGovernor.Stats()
+}
+
+// IsConnected returns true while mux is listening to its clients
 func (m *Mux) IsConnected() bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	return m.online
 }
 
+// PendingAcks returns number of authenticated requests sent via Send
+// and not yet acknowledged with a notification
+func (m *Mux) PendingAcks() int {
+	return m.acks.Pending()
+}
+
+// Close closes all connections and stops Listen. It does not block and
+// can be called regardless of Listen running. Subsequent calls are no-op
 func (m *Mux) Close() bool {
-	m.closeChan <- true
+	m.closeOnce.Do(func() {
+		close(m.closeChan)
+
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+
+		for _, v := range m.publicClients {
+			if v == nil {
+				continue
+			}
+			if err := v.Close(); err != nil {
+				log.Printf("failed closing public client: %s\n", err)
+			}
+		}
+
+		if m.privateClient != nil {
+			if err := m.privateClient.Close(); err != nil {
+				log.Printf("failed closing private client: %s\n", err)
+			}
+		}
+
+		if m.orderGovernor != nil {
+			m.orderGovernor.Close()
+		}
+
+		m.online = false
+	})
+	m.closeRoutes()
 	return true
 }
 
+// Run starts the mux and calls cb for each received message, same as Listen,
+// until ctx is done. On cancellation it waits up to shutdown timeout for
+// acknowledgements of in-flight authenticated requests and closes the mux.
+// Should be used instead of Start and Listen
+func (m *Mux) Run(ctx context.Context, cb func(interface{}, error)) error {
+	if err := m.Start().err(); err != nil {
+		m.Close()
+		return err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			if !m.acks.Wait(m.shutdownTimeout) {
+				log.Printf("closing with %d unacknowledged requests in flight\n", m.acks.Pending())
+			}
+			m.Close()
+		case <-m.closeChan:
+		}
+	}()
+
+	err := m.Listen(cb)
+	m.Close()
+	return err
+}
+
 // Subscribe - given the details in form of event.Subscribe, subscribes client to public
-// channels. If rate limit is reached, calls itself recursively after 1s with same params
+// channels. If rate limit is reached, blocks until it frees up or mux is closed
 func (m *Mux) Subscribe(sub event.Subscribe) *Mux {
-	if m.Err != nil {
+	if m.err() != nil || m.subAdded(sub) {
 		return m
 	}
 
-	// if limit is reached, wait 1 second and recuresively
-	// call Subscribe again with same subscription details
-	if m.rateLimitQueueSize == maxRateLimitQueueSize {
-		time.Sleep(1 * time.Second)
-		return m.Subscribe(sub)
+	select {
+	case <-m.subTokens:
+	case <-m.closeChan:
+		return m
 	}
 
-	m.mtx.RLock()
-	defer m.mtx.RUnlock()
-	if m.publicClients[m.cid].SubAdded(sub) {
+	// request is sent without mtx held, so slow connection does not block
+	// the rest of mux
+	cid, c := m.reserve(sub)
+	if c == nil {
 		return m
 	}
 
-	if m.Err = m.publicClients[m.cid].Subscribe(sub); m.Err != nil {
-		return m
+	if err := c.Send(sub); err != nil {
+		m.release(cid, c, sub, err)
 	}
+	return m
+}
+
+// reserve assigns subscription to a client and records it as pending, so
+// that it is not added twice while request is being sent. New client is
+// dialed if needed, returns nil client if subscription should not be sent
+func (m *Mux) reserve(sub event.Subscribe) (int, *client.Client) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
 
-	if limitReached := m.publicClients[m.cid].SubsLimitReached(); limitReached {
-		log.Printf("subs limit is reached on cid: %d, spawning new conn\n", m.cid)
-		m.addPublicClient()
+	if !m.canSubscribe(sub) {
+		return 0, nil
 	}
 
-	m.rateLimitQueueSize++
-	return m
+	cid, c := m.assign(sub)
+	if c == nil {
+		m.mtx.Unlock()
+		m.dialMtx.Lock()
+		defer m.dialMtx.Unlock()
+		m.mtx.Lock()
+
+		// state might have changed while waiting for other dial
+		if !m.canSubscribe(sub) {
+			return 0, nil
+		}
+
+		if cid, c = m.assign(sub); c == nil {
+			shard := m.shard(sub)
+			log.Printf("no public client with room for shard %q, spawning new conn\n", shard)
+
+			var err error
+			if cid, c, err = m.openPublicClient(shard); err != nil {
+				m.Err = err
+			}
+			// might have been added while dialing
+			if c == nil || !m.canSubscribe(sub) {
+				return 0, nil
+			}
+		}
+	}
+
+	c.AddSub(sub)
+	m.subs[sub] = &Subscription{Subscribe: sub, CID: cid, State: SubscriptionPending}
+	return cid, c
+}
+
+// canSubscribe returns true if subscription can be added to mux.
+// Must be called with mtx held
+func (m *Mux) canSubscribe(sub event.Subscribe) bool {
+	if m.closed() || m.Err != nil {
+		return false
+	}
+
+	if m.lastCID == 0 {
+		m.Err = errors.New("no public client available, call Start first")
+		return false
+	}
+
+	// might have been added while waiting for rate limit
+	return !m.hasSub(sub)
+}
+
+// release drops reserved subscription which request failed to be sent and
+// passes the error to mux. Nothing is dropped if client got replaced in the
+// meantime, subscription is restored on the new one then
+func (m *Mux) release(cid int, c *client.Client, sub event.Subscribe, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.publicClients[cid] != c {
+		return
+	}
+
+	c.RemoveSub(sub)
+	delete(m.subs, sub)
+	m.Err = err
 }
 
 // Start creates initial clients for accepting connections
 func (m *Mux) Start() *Mux {
-	if m.hasAPIKeys() && m.privateClient == nil {
-		m.addPrivateClient()
-	}
+	m.startOnce.Do(func() {
+		if m.hasAPIKeys() {
+			c, err := m.dialPrivateClient()
+			m.mtx.Lock()
+			if m.Err = err; err == nil {
+				m.addPrivateClient(c)
+			}
+			m.mtx.Unlock()
+			if err != nil {
+				return
+			}
+		}
+
+		m.watchRateLimit()
+		m.watchHeartbeats()
 
-	m.watchRateLimit()
-	m.addPublicClient()
+		m.dialMtx.Lock()
+		defer m.dialMtx.Unlock()
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		_, _, m.Err = m.openPublicClient("")
+	})
 	return m
 }
 
@@ -151,194 +413,384 @@ func (m *Mux) Start() *Mux {
 // receives a message from any of its clients/subscriptions. It
 // should be called last, after all setup calls are made
 func (m *Mux) Listen(cb func(interface{}, error)) error {
-	if m.Err != nil {
-		return m.Err
+	if err := m.err(); err != nil {
+		return err
+	}
+
+	if m.closed() {
+		return nil
 	}
 
-	m.online = true
+	m.setOnline(true)
+	defer m.setOnline(false)
+
+	d := &dispatcher{mux: m, cb: cb}
+	emit := d.emit
+
 	for {
 		select {
 		case ms, ok := <-m.publicChan:
 			if !ok {
 				return errors.New("public channel has closed unexpectedly")
 			}
+			d.reset(&ms, false)
 			if ms.Err != nil {
-				cb(nil, fmt.Errorf("conn:%d has failed | err:%s | reconnecting", ms.CID, ms.Err))
-				m.resetPublicClient(ms.CID)
+				if m.closed() {
+					return nil
+				}
+				emit(nil, fmt.Errorf("conn:%d has failed | err:%s | reconnecting", ms.CID, ms.Err))
+				if err := m.resetPublicClient(ms.CID); err != nil {
+					emit(nil, fmt.Errorf("conn:%d reconnect failed | err:%s", ms.CID, err))
+				}
 				continue
 			}
-			// return raw payload data if transform is off
-			if !m.transform {
-				cb(ms.Data, nil)
-				continue
+			if chID, ok := ms.ChanID(); ok {
+				m.touch(ms.CID, chID)
+				// managed books are updated regardless of transform
+				if m.manageBooks {
+					if mismatch, err := m.processBook(ms, chID); err != nil {
+						emit(nil, err)
+					} else if mismatch != nil {
+						emit(*mismatch, nil)
+					}
+				}
 			}
-			// handle event type message
+			// events are recorded regardless of transform
 			if ms.IsEvent() {
-				cb(m.recordEvent(ms.ProcessEvent()))
+				m.processEvent(ms, emit)
+				continue
+			}
+			// return raw payload data if transform is off
+			if !m.transform {
+				emit(ms.Data, nil)
 				continue
 			}
 			// handle data type message
 			if ms.IsRaw() {
 				raw, pld, chID, _, err := ms.PreprocessRaw()
 				if err != nil {
-					cb(nil, err)
+					emit(nil, err)
 					continue
 				}
 
-				inf, ok := m.subInfo[chID]
+				inf, ok := m.chanInfo(ms.CID, chID)
 				if !ok {
-					cb(nil, fmt.Errorf("unrecognized chanId:%d", chID))
+					emit(nil, fmt.Errorf("unrecognized chanId:%d", chID))
 					continue
 				}
-				cb(ms.ProcessPublic(raw, pld, chID, inf))
+				emit(ms.ProcessPublic(raw, pld, chID, inf))
 				continue
 			}
-			cb(nil, fmt.Errorf("unrecognized msg signature: %s", ms.Data))
+			emit(nil, fmt.Errorf("unrecognized msg signature: %s", ms.Data))
 		case ms, ok := <-m.privateChan:
 			if !ok {
 				return errors.New("private channel has closed unexpectedly")
 			}
+			d.reset(&ms, true)
 			if ms.Err != nil {
-				cb(nil, fmt.Errorf("err: %s | reconnecting", ms.Err))
-				m.resetPrivateClient()
+				if m.closed() {
+					return nil
+				}
+				// reconnect happens in the background, Send fails with
+				// ErrReconnecting until AuthRestored is passed to cb
+				if lost, ok := m.privateFailed(ms.Err); ok {
+					emit(lost, nil)
+				}
 				continue
 			}
-			// return raw payload data if transform is off
-			if !m.transform {
-				cb(ms.Data, nil)
-				continue
+			if chID, ok := ms.ChanID(); ok {
+				m.touch(ms.CID, chID)
 			}
-			// handle event type message
+			m.ackNotification(ms)
+			// events are recorded regardless of transform
 			if ms.IsEvent() {
-				cb(m.recordEvent(ms.ProcessEvent()))
+				m.processEvent(ms, emit)
+				continue
+			}
+			// return raw payload data if transform is off
+			if !m.transform {
+				emit(ms.Data, nil)
 				continue
 			}
 			// handle data type message
 			if ms.IsRaw() {
 				raw, pld, chID, msgType, err := ms.PreprocessRaw()
 				if err != nil {
-					cb(nil, err)
+					emit(nil, err)
 					continue
 				}
-				cb(ms.ProcessPrivate(raw, pld, chID, msgType))
+				emit(ms.ProcessPrivate(raw, pld, chID, msgType))
 				continue
 			}
-			cb(nil, fmt.Errorf("unrecognized msg signature: %s", ms.Data))
-		case <-m.closeChan:
-			m.mtx.Lock()
-			defer m.mtx.Unlock()
-
-			for _, v := range m.publicClients {
-				if v == nil {
-					continue
-				}
-				if err := v.Close(); err != nil {
-					log.Printf("failed closing public client: %s\n", err)
-				}
-			}
-
-			if m.privateClient != nil {
-				if err := m.privateClient.Close(); err != nil {
-					log.Printf("failed closing private client: %s\n", err)
-				}
+			emit(nil, fmt.Errorf("unrecognized msg signature: %s", ms.Data))
+		case ms := <-m.notices:
+			d.reset(nil, false)
+			if err, ok := ms.(error); ok {
+				emit(nil, err)
+				continue
 			}
-
-			m.online = false
+			emit(ms, nil)
+		case <-m.closeChan:
 			return nil
 		}
 	}
 }
 
 // Send meant for authenticated input, takes payload in form of interface
-// and calls client with it
+// and calls client with it. If order rate limit is set, call blocks until
+// payload leaves the queue. Order requests are tracked until acknowledged
 func (m *Mux) Send(pld interface{}) error {
-	if !m.authenticated || m.privateClient == nil {
-		return errors.New("not authorized")
+	key := m.acks.Add(pld)
+	err := m.send(context.Background(), pld)
+	if err != nil {
+		m.acks.Remove(key)
+	}
+	return err
+}
+
+func (m *Mux) send(ctx context.Context, pld interface{}) error {
+	c, err := m.authenticatedClient()
+	if err != nil {
+		return err
+	}
+	if m.orderGovernor == nil {
+		return c.Send(pld)
+	}
+
+	return m.orderGovernor.Submit(ctx, ratelimit.OrderOp(pld, func(pld interface{}) error {
+		// private client might have been reset while queued
+		c, err := m.authenticatedClient()
+		if err != nil {
+			return err
+		}
+		return c.Send(pld)
+	}))
+}
+
+func (m *Mux) closed() bool {
+	select {
+	case <-m.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Mux) err() error {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.Err
+}
+
+func (m *Mux) setOnline(online bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.online = online
+}
+
+func (m *Mux) chanInfo(cid int, chID int64) (event.Info, bool) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	inf, ok := m.subInfo[chanKey{cid, chID}]
+	return inf, ok
+}
+
+// ackNotification marks authenticated requests acknowledged by the
+// private message as done and passes it to requests awaiting it. Only
+// notifications are parsed
+func (m *Mux) ackNotification(ms msg.Msg) {
+	if (m.acks.Pending() == 0 && m.acks.Awaited() == 0) || !ms.IsRaw() {
+		return
+	}
+
+	_, pld, _, msgType, err := ms.PreprocessRaw()
+	if err != nil || msgType != "n" {
+		return
+	}
+
+	if data, ok := pld.([]interface{}); ok {
+		if n, err := notification.FromRaw(data); err == nil {
+			m.acks.Ack(n)
+		}
 	}
-	return m.privateClient.Send(pld)
 }
 
 func (m *Mux) hasAPIKeys() bool {
 	return len(m.apikey) != 0 && len(m.apisec) != 0
 }
 
-func (m *Mux) recordEvent(i event.Info, err error) (event.Info, error) {
+// processEvent records event message and passes it to cb, parsed if
+// transform is on, raw otherwise. Followed by AuthRestored if event
+// completes private client reconnect
+func (m *Mux) processEvent(ms msg.Msg, cb func(interface{}, error)) {
+	var restored interface{}
+	i, err := ms.ProcessEvent()
+	if err == nil {
+		restored = m.recordEvent(ms.CID, i)
+	}
+
+	if !m.transform {
+		cb(ms.Data, nil)
+	} else {
+		cb(i, err)
+	}
+
+	if restored != nil {
+		cb(restored, nil)
+	}
+}
+
+func (m *Mux) recordEvent(cid int, i event.Info) interface{} {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
 	switch i.Event {
 	case "subscribed":
-		m.subInfo[i.ChanID] = i
+		m.recordSubscribed(cid, i)
+	case "unsubscribed":
+		m.recordUnsubscribed(cid, i)
+	case "error":
+		m.recordSubscribeError(cid, i)
 	case "auth":
-		if i.Status == "OK" {
-			m.subInfo[i.ChanID] = i
-			m.authenticated = true
-		}
+		return m.recordAuth(cid, i)
 	}
 	// add more cases if/when needed
-	return i, err
+	return nil
 }
 
-func (m *Mux) resetPublicClient(cid int) {
-	// pull old client subscriptions
-	subs := m.publicClients[cid].GetAllSubs()
-	// add fresh client
-	m.addPublicClient()
-	// resubscribe old events
-	for _, sub := range subs {
-		log.Printf("resubscribing: %+v\n", sub)
-		m.Subscribe(sub)
+// resetPublicClient replaces failed client with a fresh one and resubscribes
+// its subscriptions in the background, so that Listen is not blocked by rate limit
+func (m *Mux) resetPublicClient(cid int) error {
+	m.mtx.Lock()
+	old, ok := m.publicClients[cid]
+	if !ok || m.closed() {
+		m.mtx.Unlock()
+		return nil
 	}
-	// remove old, closed channel from the list
+
+	// pull old client subscriptions and remove it from the list
+	subs := m.forgetClientSubs(cid)
 	delete(m.publicClients, cid)
-}
+	shard := m.shards[cid]
+	delete(m.shards, cid)
+	m.mtx.Unlock()
+
+	if err := old.Close(); err != nil {
+		log.Printf("failed closing public client: %s\n", err)
+	}
+
+	// add fresh client to the same shard, subscriptions are assigned
+	// again following sharding strategy
+	m.dialMtx.Lock()
+	m.mtx.Lock()
+	_, _, err := m.openPublicClient(shard)
+	if err != nil {
+		m.Err = err
+	}
+	m.mtx.Unlock()
+	m.dialMtx.Unlock()
+	if err != nil {
+		return err
+	}
 
-func (m *Mux) resetPrivateClient() {
-	m.authenticated = false
-	m.privateClient = nil
-	m.addPrivateClient()
+	// resubscribe old events
+	go func() {
+		for _, sub := range subs {
+			log.Printf("resubscribing: %+v\n", sub)
+			m.Subscribe(sub)
+		}
+	}()
+	return nil
 }
 
-func (m *Mux) addPublicClient() *Mux {
+// openPublicClient dials new public client, adds it to given shard and makes
+// it current one. Must be called with mtx held, which is released while
+// dialing. Returns nil client without error if mux got closed meanwhile
+func (m *Mux) openPublicClient(shard string) (int, *client.Client, error) {
 	// adding new client so making sure we increment cid
-	m.cid++
-	// create new public client and pass error to mux if any
+	m.lastCID++
+	cid := m.lastCID
+
+	m.mtx.Unlock()
 	c, err := client.
 		New().
-		WithID(m.cid).
-		WithSubsLimit(30).
+		WithID(cid).
+		WithSubsLimit(m.subsLimit).
+		WithDialer(m.dialer).
+		WithFlags(m.flags()).
 		Public(m.publicURL)
+	m.mtx.Lock()
 	if err != nil {
-		m.Err = err
-		return m
+		return 0, nil, err
+	}
+
+	if m.closed() {
+		if err := c.Close(); err != nil {
+			log.Printf("failed closing public client: %s\n", err)
+		}
+		return 0, nil, nil
 	}
+
 	// add new client to list for later reference
-	m.publicClients[m.cid] = c
+	m.publicClients[cid] = c
+	m.shards[cid] = shard
+	m.current[shard] = cid
 	// start listening for incoming client messages
 	go c.Read(m.publicChan)
-	return m
+	return cid, c, nil
 }
 
-func (m *Mux) addPrivateClient() *Mux {
-	// create new private client and pass error to mux if any
-	c, err := client.New().Private(m.apikey, m.apisec, m.authURL, m.dms)
-	if err != nil {
-		m.Err = err
-		return m
+// flags returns configuration flags enabled on public connections
+func (m *Mux) flags() (flags int) {
+	if m.manageBooks {
+		flags |= common.Checksum
+	}
+	return
+}
+
+// dialPrivateClient creates new private client, sending auth request.
+// Does not touch mux state, so it is called without mtx held
+func (m *Mux) dialPrivateClient() (*client.Client, error) {
+	return client.
+		New().
+		WithFilter(m.authFilter).
+		WithNonceGenerator(m.nonceGen).
+		WithDialer(m.dialer).
+		Private(m.apikey, m.apisec, m.authURL, m.dms)
+}
+
+// addPrivateClient starts reading from dialed private client, closing it
+// instead if mux got closed or another one was added while dialing. Must be
+// called with mtx held
+func (m *Mux) addPrivateClient(c *client.Client) {
+	if m.closed() || m.privateClient != nil {
+		if err := c.Close(); err != nil {
+			log.Printf("failed closing private client: %s\n", err)
+		}
+		return
 	}
 
 	m.privateClient = c
 	go c.Read(m.privateChan)
-	return m
 }
 
 // watchRateLimit will run once every rateLimitDuration
-// and free up the queue
+// and free up the subscriptions queue, until mux is closed
 func (m *Mux) watchRateLimit() {
 	go func() {
+		ticker := time.NewTicker(rateLimitDuration)
+		defer ticker.Stop()
+
 		for {
-			if m.rateLimitQueueSize > 0 {
-				m.rateLimitQueueSize--
+			select {
+			case <-ticker.C:
+				select {
+				case m.subTokens <- struct{}{}:
+				default:
+				}
+			case <-m.closeChan:
+				return
 			}
-
-			time.Sleep(rateLimitDuration)
 		}
 	}()
 }
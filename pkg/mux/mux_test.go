@@ -0,0 +1,600 @@
+package mux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is an in-memory websocket server which replies to each client
+// message with messages returned by respond and records everything it receives.
+// Mux reaches it through pipeDialer, no network is involved
+type testServer struct {
+	url     string
+	respond func(req []byte) []string
+	// reject is number of upcoming connection attempts to refuse
+	reject int32
+
+	mtx   sync.Mutex
+	conns []net.Conn
+	outs  []chan string
+	reqs  []string
+}
+
+var (
+	serversMtx sync.Mutex
+	servers    = map[string]*testServer{}
+	lastServer int
+)
+
+// pipeDialer connects clients to registered test servers over net.Pipe
+var pipeDialer = client.DialerFunc(func(ctx context.Context, url string) (net.Conn, error) {
+	serversMtx.Lock()
+	ts, ok := servers[url]
+	serversMtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("dial %s: connection refused", url)
+	}
+
+	if atomic.AddInt32(&ts.reject, -1) >= 0 {
+		return nil, fmt.Errorf("dial %s: service unavailable", url)
+	}
+
+	clientConn, serverConn := net.Pipe()
+	out := make(chan string, 1024)
+	ts.mtx.Lock()
+	ts.conns = append(ts.conns, serverConn)
+	ts.outs = append(ts.outs, out)
+	ts.mtx.Unlock()
+
+	go ts.serve(serverConn, out)
+	return clientConn, nil
+})
+
+// newMux returns mux connecting to test servers
+func newMux() *mux.Mux {
+	return mux.New().WithDialer(pipeDialer)
+}
+
+// newTestServer starts test server which replies to each client
+// message with messages returned by respond, respondDefault if nil
+func newTestServer(t *testing.T, respond func(req []byte) []string) (*testServer, string) {
+	if respond == nil {
+		respond = respondDefault
+	}
+
+	serversMtx.Lock()
+	defer serversMtx.Unlock()
+	lastServer++
+	ts := &testServer{
+		respond: respond,
+		url:     fmt.Sprintf("pipe://%s/%d", t.Name(), lastServer),
+	}
+	servers[ts.url] = ts
+
+	return ts, ts.url
+}
+
+// serve reads client frames and writes replies. Writes are queued the way
+// socket buffers do, so that server never blocks reading on a slow client
+func (ts *testServer) serve(conn net.Conn, out chan string) {
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case res := <-out:
+				if err := wsutil.WriteServerText(conn, []byte(res)); err != nil {
+					conn.Close()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		req, _, err := wsutil.ReadClientData(conn)
+		if err != nil {
+			return
+		}
+
+		ts.mtx.Lock()
+		ts.reqs = append(ts.reqs, string(req))
+		ts.mtx.Unlock()
+
+		for _, res := range ts.respond(req) {
+			out <- res
+		}
+	}
+}
+
+// push sends message to all connections accepted so far
+func (ts *testServer) push(ms string) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, out := range ts.outs {
+		select {
+		case out <- ms:
+		default:
+		}
+	}
+}
+
+// Close stops accepting connections and drops existing ones
+func (ts *testServer) Close() {
+	serversMtx.Lock()
+	delete(servers, ts.url)
+	serversMtx.Unlock()
+	ts.dropConns()
+}
+
+// dropConns closes all connections accepted so far
+func (ts *testServer) dropConns() {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, c := range ts.conns {
+		c.Close()
+	}
+}
+
+// connCount returns number of connections accepted so far
+func (ts *testServer) connCount() int {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	return len(ts.conns)
+}
+
+// count returns number of received requests containing substr
+func (ts *testServer) count(substr string) (n int) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, r := range ts.reqs {
+		if strings.Contains(r, substr) {
+			n++
+		}
+	}
+	return
+}
+
+// eventually fails the test if cond does not become true within a second
+func eventually(t *testing.T, cond func() bool, msg string) {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal(msg)
+}
+
+var lastChanID int64
+
+// respondDefault acknowledges auth, subscribe and unsubscribe requests
+// the way api does, echoing subscription details with a fresh chanId
+func respondDefault(req []byte) []string {
+	var sub struct {
+		event.Subscribe
+		ChanID int64 `json:"chanId"`
+	}
+	if err := json.Unmarshal(req, &sub); err != nil {
+		return nil
+	}
+
+	switch sub.Event {
+	case "auth":
+		return []string{`{"event":"auth","status":"OK","chanId":0,"userId":1}`}
+	case "subscribe":
+		sub.Event = "subscribed"
+		sub.ChanID = atomic.AddInt64(&lastChanID, 1)
+		b, _ := json.Marshal(sub)
+		return []string{string(b)}
+	case "unsubscribe":
+		return []string{fmt.Sprintf(`{"event":"unsubscribed","status":"OK","chanId":%d}`, sub.ChanID)}
+	}
+	return nil
+}
+
+// waitForGoroutines fails the test if number of running goroutines does not
+// drop back to given baseline
+func waitForGoroutines(t *testing.T, baseline int) {
+	for i := 0; i < 100; i++ {
+		if runtime.NumGoroutine() <= baseline {
+			return
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+	buf := make([]byte, 1<<16)
+	n := runtime.Stack(buf, true)
+	t.Fatalf("leaked goroutines, expected %d got %d:\n%s", baseline, runtime.NumGoroutine(), buf[:n])
+}
+
+func TestCloseWithoutListen(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	srv, url := newTestServer(t, respondDefault)
+
+	m := newMux().WithPublicURL(url).Start()
+	require.Nil(t, m.Err)
+
+	closed := make(chan bool)
+	go func() {
+		closed <- m.Close()
+	}()
+
+	select {
+	case ok := <-closed:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked without Listen running")
+	}
+
+	// Listen returns right away on closed mux
+	assert.Nil(t, m.Listen(func(interface{}, error) {}))
+	assert.True(t, m.Close())
+
+	srv.Close()
+	waitForGoroutines(t, baseline)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("returns when context is cancelled", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+		srv, url := newTestServer(t, respondDefault)
+
+		m := newMux().WithPublicURL(url).TransformRaw()
+		ctx, cancel := context.WithCancel(context.Background())
+		subscribed := make(chan struct{}, 1)
+		errs := make(chan error, 1)
+		go func() {
+			errs <- m.Run(ctx, func(ms interface{}, err error) {
+				if i, ok := ms.(event.Info); ok && i.Event == "subscribed" {
+					subscribed <- struct{}{}
+				}
+			})
+		}()
+
+		eventually(t, m.IsConnected, "mux did not start listening")
+		m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"})
+
+		select {
+		case <-subscribed:
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not acknowledged")
+		}
+
+		cancel()
+		select {
+		case err := <-errs:
+			assert.Nil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Run did not return after context was cancelled")
+		}
+
+		srv.Close()
+		waitForGoroutines(t, baseline)
+	})
+
+	t.Run("drains in-flight order acknowledgements", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+		srv, url := newTestServer(t, func(req []byte) []string {
+			if bytes.Contains(req, []byte(`"on"`)) {
+				// acknowledge new order with a delay
+				time.Sleep(time.Millisecond * 200)
+				return []string{`[0,"n",[1575289447641,"on-req",null,null,[1185815100,null,788,"tBTCUSD",1575289350475,1575289350501,0.001,0.001,"EXCHANGE LIMIT",null,null,null,0,"ACTIVE",null,null,7000,0,0,0,null,null,null,0,0,null,null,null,"API>BFX",null,null,null],null,"SUCCESS","Submitting exchange limit buy order for 0.001 BTC."]]`}
+			}
+			return respondDefault(req)
+		})
+
+		m := newMux().
+			WithPublicURL(url).
+			WithAuthURL(url).
+			WithAPIKEY("key").
+			WithAPISEC("sec").
+			WithShutdownTimeout(time.Second * 2).
+			TransformRaw()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		authenticated := make(chan struct{}, 1)
+		acked := make(chan *notification.Notification, 1)
+		errs := make(chan error, 1)
+		go func() {
+			errs <- m.Run(ctx, func(ms interface{}, err error) {
+				switch v := ms.(type) {
+				case event.Info:
+					if v.Event == "auth" {
+						authenticated <- struct{}{}
+					}
+				case *notification.Notification:
+					acked <- v
+				}
+			})
+		}()
+
+		select {
+		case <-authenticated:
+		case <-time.After(time.Second):
+			t.Fatal("mux was not authenticated")
+		}
+
+		require.Nil(t, m.Send(&order.NewRequest{CID: 788, Symbol: "tBTCUSD"}))
+		assert.Equal(t, 1, m.PendingAcks())
+		cancel()
+
+		select {
+		case n := <-acked:
+			assert.Equal(t, "on-req", n.Type)
+		case <-errs:
+			t.Fatal("Run returned before in-flight order was acknowledged")
+		case <-time.After(time.Second):
+			t.Fatal("order was not acknowledged")
+		}
+
+		select {
+		case err := <-errs:
+			assert.Nil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Run did not return after order was acknowledged")
+		}
+		assert.Equal(t, 0, m.PendingAcks())
+
+		srv.Close()
+		waitForGoroutines(t, baseline)
+	})
+}
+
+func TestConcurrentSubscribe(t *testing.T) {
+	srv, url := newTestServer(t, respondDefault)
+	defer srv.Close()
+
+	m := newMux().WithPublicURL(url).TransformRaw().Start()
+	require.Nil(t, m.Err)
+	defer m.Close()
+
+	go func() {
+		assert.Nil(t, m.Listen(func(interface{}, error) {}))
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sub := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: fmt.Sprintf("tSYM%d", i)}
+			m.Subscribe(sub)
+			// duplicates are ignored
+			m.Subscribe(sub)
+			m.IsConnected()
+			assert.NotNil(t, m.Send(&order.NewRequest{CID: int64(i)}))
+		}(i)
+	}
+	wg.Wait()
+
+	eventually(t, func() bool { return srv.count(`"event":"subscribe"`) == 10 }, "expected 10 subscriptions")
+	assert.Nil(t, m.Err)
+	assert.Equal(t, 0, m.PendingAcks())
+}
+
+func TestReconnectResubscribes(t *testing.T) {
+	srv, url := newTestServer(t, respondDefault)
+	defer srv.Close()
+
+	m := newMux().WithPublicURL(url).TransformRaw().Start()
+	require.Nil(t, m.Err)
+	defer m.Close()
+
+	errs := make(chan error, 10)
+	go func() {
+		assert.Nil(t, m.Listen(func(_ interface{}, err error) {
+			if err != nil {
+				errs <- err
+			}
+		}))
+	}()
+
+	for _, symbol := range []string{"tBTCUSD", "tETHUSD", "tLTCUSD"} {
+		m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: symbol})
+	}
+	eventually(t, func() bool { return srv.count(`"event":"subscribe"`) == 3 }, "expected initial subscriptions")
+
+	// server drops the connection, mux reconnects and subscribes again
+	srv.dropConns()
+	eventually(t, func() bool { return srv.connCount() == 2 }, "expected mux to reconnect")
+	eventually(t, func() bool { return srv.count(`"event":"subscribe"`) == 6 }, "expected resubscriptions")
+
+	select {
+	case err := <-errs:
+		assert.Contains(t, err.Error(), "reconnecting")
+	default:
+		t.Fatal("expected connection failure to be reported")
+	}
+}
+
+func TestConcurrentClose(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	srv, url := newTestServer(t, respondDefault)
+
+	m := newMux().WithPublicURL(url).TransformRaw().Start()
+	require.Nil(t, m.Err)
+
+	listening := make(chan error, 1)
+	go func() {
+		listening <- m.Listen(func(interface{}, error) {})
+	}()
+	eventually(t, m.IsConnected, "mux did not start listening")
+
+	var wg sync.WaitGroup
+	// keep subscribing past the rate limit, blocked calls are released on close
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "trades", Symbol: fmt.Sprintf("t%d%d", i, j)})
+			}
+		}(i)
+	}
+
+	// connection failures trigger reconnects while closing
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		srv.dropConns()
+	}()
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond * 20)
+			assert.True(t, m.Close())
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 2):
+		t.Fatal("concurrent subscribe and close did not finish")
+	}
+
+	select {
+	case err := <-listening:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Listen did not return after close")
+	}
+	assert.False(t, m.IsConnected())
+
+	srv.Close()
+	waitForGoroutines(t, baseline)
+}
+
+// gatedConn blocks writes while gate is set, simulating a connection
+// which stopped draining its socket buffer
+type gatedConn struct {
+	net.Conn
+	gate *atomic.Value
+}
+
+func (c gatedConn) Write(b []byte) (int, error) {
+	if gate, ok := c.gate.Load().(chan struct{}); ok {
+		<-gate
+	}
+	return c.Conn.Write(b)
+}
+
+func TestSlowConnectionDoesNotBlockMux(t *testing.T) {
+	sub := func(symbol string) event.Subscribe {
+		return event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: symbol}
+	}
+
+	// responsive fails the test if mux state can not be read while
+	// connection is stuck
+	responsive := func(t *testing.T, m *mux.Mux) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			m.IsConnected()
+			m.Subscriptions()
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("mux blocked by slow connection")
+		}
+	}
+
+	t.Run("dial", func(t *testing.T) {
+		srv, url := newTestServer(t, respondDefault)
+		defer srv.Close()
+
+		release := make(chan struct{})
+		dials := int32(0)
+		dialer := client.DialerFunc(func(ctx context.Context, url string) (net.Conn, error) {
+			if atomic.AddInt32(&dials, 1) > 1 {
+				<-release
+			}
+			return pipeDialer.Dial(ctx, url)
+		})
+
+		m := mux.New().WithDialer(dialer).WithPublicURL(url).WithSubsLimit(1).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+
+		go func() {
+			assert.Nil(t, m.Listen(func(interface{}, error) {}))
+		}()
+
+		m.Subscribe(sub("tBTCUSD"))
+		// second subscription does not fit, new connection is being dialed
+		go m.Subscribe(sub("tETHUSD"))
+		eventually(t, func() bool { return atomic.LoadInt32(&dials) == 2 }, "expected second dial")
+
+		responsive(t, m)
+		assert.Len(t, m.Subscriptions(), 1)
+
+		close(release)
+		eventually(t, func() bool { return srv.count(`"event":"subscribe"`) == 2 }, "expected subscription after dial")
+		assert.Nil(t, m.Err)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		srv, url := newTestServer(t, respondDefault)
+		defer srv.Close()
+
+		gate := &atomic.Value{}
+		dialer := client.DialerFunc(func(ctx context.Context, url string) (net.Conn, error) {
+			conn, err := pipeDialer.Dial(ctx, url)
+			if err != nil {
+				return nil, err
+			}
+			return gatedConn{Conn: conn, gate: gate}, nil
+		})
+
+		m := mux.New().WithDialer(dialer).WithPublicURL(url).TransformRaw().Start()
+		require.Nil(t, m.Err)
+		defer m.Close()
+
+		go func() {
+			assert.Nil(t, m.Listen(func(interface{}, error) {}))
+		}()
+
+		stalled := make(chan struct{})
+		gate.Store(stalled)
+		go m.Subscribe(sub("tBTCUSD"))
+		eventually(t, func() bool { return len(m.Subscriptions()) == 1 }, "expected pending subscription")
+
+		responsive(t, m)
+		assert.Equal(t, mux.SubscriptionPending, m.Subscriptions()[0].State)
+
+		close(stalled)
+		eventually(t, func() bool { return srv.count(`"event":"subscribe"`) == 1 }, "expected subscription after write")
+		assert.Nil(t, m.Err)
+	})
+}
@@ -0,0 +1,312 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/book"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
+)
+
+// Orderbook is a point in time copy of a managed trading pair order book
+type Orderbook struct {
+	Symbol    string
+	Precision string
+	// Bids are ordered by price descending, Asks by price ascending
+	Bids []book.Book
+	Asks []book.Book
+	// Checksum is crc32 of top 25 levels, computed the way api does
+	Checksum uint32
+	// Verified is set once a checksum sent by api matched the book
+	Verified bool
+}
+
+// ChecksumMismatch is passed to Listen callback when managed book checksum
+// does not match the one sent by api. Book is dropped and resubscribed
+type ChecksumMismatch struct {
+	Subscription
+	Expected uint32
+	Got      uint32
+}
+
+// managedBook is maintained from book channel snapshot and updates
+type managedBook struct {
+	symbol    string
+	precision string
+	bids      []*book.Book
+	asks      []*book.Book
+	synced    bool
+	verified  bool
+}
+
+// WithManagedBooks enables checksums on public connections and maintains
+// trading pair order books of book subscriptions, see Book. Books failing
+// checksum verification get resubscribed
+func (m *Mux) WithManagedBooks() *Mux {
+	m.manageBooks = true
+	return m
+}
+
+// Book returns copy of managed order book subscribed with given symbol and
+// precision, P0 if empty. Returns false if there is no such subscription or
+// its snapshot has not been received yet
+func (m *Mux) Book(symbol, precision string) (Orderbook, bool) {
+	if precision == "" {
+		precision = "P0"
+	}
+
+	m.booksMtx.RLock()
+	defer m.booksMtx.RUnlock()
+
+	for _, b := range m.books {
+		if b.symbol == symbol && b.precision == precision && b.synced {
+			return b.copy(), true
+		}
+	}
+	return Orderbook{}, false
+}
+
+// addBook starts managing book of confirmed subscription. Must be called with mtx held
+func (m *Mux) addBook(cid int, i event.Info) {
+	if !m.manageBooks || i.Channel != "book" || !strings.HasPrefix(i.Symbol, common.TradingPrefix) {
+		return
+	}
+
+	m.booksMtx.Lock()
+	defer m.booksMtx.Unlock()
+	m.books[chanKey{cid, i.ChanID}] = &managedBook{symbol: i.Symbol, precision: i.Precision}
+}
+
+// forgetBooks stops managing books of given client, or only given channel
+// if chID is not negative. Must be called with mtx held
+func (m *Mux) forgetBooks(cid int, chID int64) {
+	m.booksMtx.Lock()
+	defer m.booksMtx.Unlock()
+	for k := range m.books {
+		if k.cid == cid && (chID < 0 || k.chanID == chID) {
+			delete(m.books, k)
+		}
+	}
+}
+
+// processBook applies book channel message to managed book. Returns
+// ChecksumMismatch if book got out of sync and is being resubscribed
+func (m *Mux) processBook(ms msg.Msg, chID int64) (*ChecksumMismatch, error) {
+	m.booksMtx.RLock()
+	_, ok := m.books[chanKey{ms.CID, chID}]
+	m.booksMtx.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+
+	// frame is split without decoding, entries are parsed once by book
+	// models, keeping numbers in their original representation for checksum
+	var buf [4][]byte
+	raw, err := convert.SplitArray(buf[:0], ms.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < 2 {
+		return nil, fmt.Errorf("unexpected book msg: %s", ms.Data)
+	}
+
+	if raw[1][0] == '"' {
+		var op string
+		if err := json.Unmarshal(raw[1], &op); err != nil {
+			return nil, err
+		}
+		if op != "cs" || len(raw) < 3 {
+			return nil, nil
+		}
+		expected, err := strconv.ParseInt(string(raw[2]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected book checksum: %s", ms.Data)
+		}
+		return m.verifyBook(ms.CID, chID, uint32(int32(expected))), nil
+	}
+
+	data := raw[1]
+	if data[0] != '[' {
+		return nil, nil
+	}
+
+	m.booksMtx.Lock()
+	defer m.booksMtx.Unlock()
+
+	b, ok := m.books[chanKey{ms.CID, chID}]
+	if !ok {
+		return nil, nil
+	}
+
+	switch bytes.TrimLeft(data[1:], " \t\r\n")[0] {
+	case ']':
+		// snapshot of an empty book
+		b.reset(&book.Snapshot{})
+		return nil, nil
+	case '[':
+		snap, err := book.SnapshotFromJSON(b.symbol, b.precision, data)
+		if err != nil {
+			return nil, err
+		}
+		b.reset(snap)
+		return nil, nil
+	}
+
+	update, err := book.FromJSON(b.symbol, b.precision, data)
+	if err != nil {
+		return nil, err
+	}
+	b.update(update)
+	return nil, nil
+}
+
+// verifyBook compares book checksum with expected one and resubscribes
+// the book on mismatch
+func (m *Mux) verifyBook(cid int, chID int64, expected uint32) *ChecksumMismatch {
+	m.booksMtx.Lock()
+	b, ok := m.books[chanKey{cid, chID}]
+	if !ok || !b.synced {
+		m.booksMtx.Unlock()
+		return nil
+	}
+
+	got := b.checksum()
+	if got == expected {
+		b.verified = true
+		m.booksMtx.Unlock()
+		return nil
+	}
+	m.booksMtx.Unlock()
+
+	log.Printf("book %s %s checksum mismatch, expected: %d, got: %d, resubscribing\n", b.symbol, b.precision, expected, got)
+
+	mismatch := m.resubscribeBook(cid, chID)
+	if mismatch == nil {
+		return nil
+	}
+
+	m.unsubscribeStale(cid, chID)
+	mismatch.Expected = expected
+	mismatch.Got = got
+	return mismatch
+}
+
+// resubscribeBook drops managed book and marks its channel to be
+// resubscribed, returns nil if there is no active subscription for it
+func (m *Mux) resubscribeBook(cid int, chID int64) *ChecksumMismatch {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.forgetBooks(cid, chID)
+	for _, s := range m.subs {
+		if s.CID == cid && s.ChanID == chID && s.State == SubscriptionActive {
+			m.resubscribe(s, time.Now())
+			return &ChecksumMismatch{Subscription: *s}
+		}
+	}
+	return nil
+}
+
+func (b *managedBook) reset(snap *book.Snapshot) {
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	b.synced = true
+	b.verified = false
+	for _, e := range snap.Snapshot {
+		b.update(e)
+	}
+}
+
+func (b *managedBook) raw() bool {
+	return book.IsRawBook(b.precision)
+}
+
+// update applies single entry. Aggregated books are keyed by price, raw by order id
+func (b *managedBook) update(e *book.Book) {
+	side := &b.asks
+	if e.Side == common.Bid {
+		side = &b.bids
+	}
+
+	// entry might have moved between sides or price levels
+	b.asks = b.remove(b.asks, e)
+	b.bids = b.remove(b.bids, e)
+	if e.Action == book.BookRemoveEntry {
+		return
+	}
+
+	bid := e.Side == common.Bid
+	idx := sort.Search(len(*side), func(i int) bool {
+		o := (*side)[i]
+		if o.Price != e.Price {
+			if bid {
+				return o.Price < e.Price
+			}
+			return o.Price > e.Price
+		}
+		return o.ID > e.ID
+	})
+
+	*side = append(*side, nil)
+	copy((*side)[idx+1:], (*side)[idx:])
+	(*side)[idx] = e
+}
+
+func (b *managedBook) remove(side []*book.Book, e *book.Book) []*book.Book {
+	for i, o := range side {
+		if (b.raw() && o.ID == e.ID) || (!b.raw() && o.Price == e.Price) {
+			return append(side[:i], side[i+1:]...)
+		}
+	}
+	return side
+}
+
+// checksum is crc32 of top 25 bids and asks, interleaved, see
+// https://docs.bitfinex.com/docs/ws-websocket-checksum
+func (b *managedBook) checksum() uint32 {
+	items := make([]string, 0, 100)
+	for i := 0; i < 25; i++ {
+		if i < len(b.bids) {
+			items = append(items, b.key(b.bids[i]), b.bids[i].AmountJsNum.String())
+		}
+		if i < len(b.asks) {
+			items = append(items, b.key(b.asks[i]), b.asks[i].AmountJsNum.String())
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(strings.Join(items, ":")))
+}
+
+func (b *managedBook) key(e *book.Book) string {
+	if b.raw() {
+		return strconv.FormatInt(e.ID, 10)
+	}
+	return e.PriceJsNum.String()
+}
+
+func (b *managedBook) copy() Orderbook {
+	ob := Orderbook{
+		Symbol:    b.symbol,
+		Precision: b.precision,
+		Bids:      make([]book.Book, len(b.bids)),
+		Asks:      make([]book.Book, len(b.asks)),
+		Checksum:  b.checksum(),
+		Verified:  b.verified,
+	}
+	for i, e := range b.bids {
+		ob.Bids[i] = *e
+	}
+	for i, e := range b.asks {
+		ob.Asks[i] = *e
+	}
+	return ob
+}
@@ -0,0 +1,174 @@
+package mux_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respondBook confirms book subscriptions followed by given snapshot
+func respondBook(snapshot string) func(req []byte) []string {
+	return func(req []byte) []string {
+		res := respondDefault(req)
+		if !bytes.Contains(req, []byte(`"event":"subscribe"`)) || len(res) == 0 {
+			return res
+		}
+
+		var i event.Info
+		if err := json.Unmarshal([]byte(res[0]), &i); err != nil {
+			return res
+		}
+		return append(res, fmt.Sprintf(`[%d,%s]`, i.ChanID, snapshot))
+	}
+}
+
+// checksum returns checksum of given book levels the way api sends it
+func checksum(levels string) int32 {
+	return int32(crc32.ChecksumIEEE([]byte(levels)))
+}
+
+func startBookMux(t *testing.T, url string) (*mux.Mux, chan mux.ChecksumMismatch) {
+	m := newMux().WithPublicURL(url).WithManagedBooks().TransformRaw().Start()
+	require.Nil(t, m.Err)
+
+	mismatches := make(chan mux.ChecksumMismatch, 10)
+	go func() {
+		assert.Nil(t, m.Listen(func(ms interface{}, err error) {
+			assert.Nil(t, err)
+			if cm, ok := ms.(mux.ChecksumMismatch); ok {
+				mismatches <- cm
+			}
+		}))
+	}()
+	eventually(t, m.IsConnected, "mux did not start listening")
+	return m, mismatches
+}
+
+func TestManagedBook(t *testing.T) {
+	sub := event.Subscribe{Event: "subscribe", Channel: "book", Symbol: "tBTCUSD", Precision: "P0"}
+	snapshot := `[[7000,1,0.5],[6999,2,1.5],[7001,1,-0.25],[7002,3,-2]]`
+
+	t.Run("maintains and verifies book", func(t *testing.T) {
+		srv, url := newTestServer(t, respondBook(snapshot))
+		defer srv.Close()
+
+		m, mismatches := startBookMux(t, url)
+		defer m.Close()
+
+		m.Subscribe(sub)
+		eventually(t, func() bool { _, ok := m.Book("tBTCUSD", ""); return ok }, "expected book snapshot")
+		assert.Equal(t, 1, srv.count(`{"event":"conf","flags":131072}`))
+
+		ob, _ := m.Book("tBTCUSD", "P0")
+		require.Len(t, ob.Bids, 2)
+		require.Len(t, ob.Asks, 2)
+		assert.Equal(t, 7000.0, ob.Bids[0].Price)
+		assert.Equal(t, 6999.0, ob.Bids[1].Price)
+		assert.Equal(t, 7001.0, ob.Asks[0].Price)
+		assert.Equal(t, 7002.0, ob.Asks[1].Price)
+		assert.False(t, ob.Verified)
+
+		chID := m.Subscriptions()[0].ChanID
+		// remove ask level, add bid level, update bid level
+		srv.push(fmt.Sprintf(`[%d,[7001,0,-1]]`, chID))
+		srv.push(fmt.Sprintf(`[%d,[7000.5,1,0.1]]`, chID))
+		srv.push(fmt.Sprintf(`[%d,[6999,3,2]]`, chID))
+		srv.push(fmt.Sprintf(`[%d,"cs",%d]`, chID, checksum("7000.5:0.1:7002:-2:7000:0.5:6999:2")))
+
+		eventually(t, func() bool { ob, _ := m.Book("tBTCUSD", "P0"); return ob.Verified }, "expected book to be verified")
+		ob, _ = m.Book("tBTCUSD", "P0")
+		require.Len(t, ob.Bids, 3)
+		require.Len(t, ob.Asks, 1)
+		assert.Equal(t, []float64{7000.5, 7000, 6999}, []float64{ob.Bids[0].Price, ob.Bids[1].Price, ob.Bids[2].Price})
+		assert.Equal(t, 2.0, ob.Bids[2].Amount)
+		assert.Equal(t, 7002.0, ob.Asks[0].Price)
+		assert.Equal(t, uint32(checksum("7000.5:0.1:7002:-2:7000:0.5:6999:2")), ob.Checksum)
+
+		assert.Len(t, mismatches, 0)
+		assert.Equal(t, 0, srv.count(`"event":"unsubscribe"`))
+
+		// copy is not affected by further updates
+		srv.push(fmt.Sprintf(`[%d,[7002,0,-1]]`, chID))
+		eventually(t, func() bool { ob, _ := m.Book("tBTCUSD", "P0"); return len(ob.Asks) == 0 }, "expected ask to be removed")
+		assert.Len(t, ob.Asks, 1)
+	})
+
+	t.Run("resubscribes on checksum mismatch", func(t *testing.T) {
+		srv, url := newTestServer(t, respondBook(snapshot))
+		defer srv.Close()
+
+		m, mismatches := startBookMux(t, url)
+		defer m.Close()
+
+		m.Subscribe(sub)
+		eventually(t, func() bool { _, ok := m.Book("tBTCUSD", "P0"); return ok }, "expected book snapshot")
+
+		chID := m.Subscriptions()[0].ChanID
+		srv.push(fmt.Sprintf(`[%d,"cs",%d]`, chID, 12345))
+
+		select {
+		case cm := <-mismatches:
+			assert.Equal(t, sub, cm.Subscribe)
+			assert.Equal(t, chID, cm.ChanID)
+			assert.Equal(t, uint32(12345), cm.Expected)
+			assert.Equal(t, uint32(checksum("7000:0.5:7001:-0.25:6999:1.5:7002:-2")), cm.Got)
+		case <-time.After(time.Second):
+			t.Fatal("expected checksum mismatch")
+		}
+
+		eventually(t, func() bool {
+			subs := subsInState(m, mux.SubscriptionActive)
+			return len(subs) == 1 && subs[0].ChanID != chID
+		}, "expected book to be resubscribed")
+		eventually(t, func() bool { _, ok := m.Book("tBTCUSD", "P0"); return ok }, "expected fresh book snapshot")
+		assert.Equal(t, 1, srv.count(fmt.Sprintf(`{"event":"unsubscribe","chanId":%d}`, chID)))
+		assert.Equal(t, 2, srv.count(`"channel":"book"`))
+	})
+
+	t.Run("raw book", func(t *testing.T) {
+		srv, url := newTestServer(t, respondBook(`[[101,7000,0.5],[102,7000,0.25],[103,7001,-1]]`))
+		defer srv.Close()
+
+		m, mismatches := startBookMux(t, url)
+		defer m.Close()
+
+		m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "book", Symbol: "tBTCUSD", Precision: "R0"})
+		eventually(t, func() bool { _, ok := m.Book("tBTCUSD", "R0"); return ok }, "expected book snapshot")
+		_, ok := m.Book("tBTCUSD", "P0")
+		assert.False(t, ok)
+
+		chID := m.Subscriptions()[0].ChanID
+		srv.push(fmt.Sprintf(`[%d,[102,0,1]]`, chID))
+		srv.push(fmt.Sprintf(`[%d,[104,6998,1]]`, chID))
+		srv.push(fmt.Sprintf(`[%d,"cs",%d]`, chID, checksum("101:0.5:103:-1:104:1")))
+
+		eventually(t, func() bool { ob, _ := m.Book("tBTCUSD", "R0"); return ob.Verified }, "expected book to be verified")
+		ob, _ := m.Book("tBTCUSD", "R0")
+		require.Len(t, ob.Bids, 2)
+		assert.Equal(t, int64(101), ob.Bids[0].ID)
+		assert.Equal(t, int64(104), ob.Bids[1].ID)
+		assert.Len(t, mismatches, 0)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, url := newTestServer(t, respondBook(snapshot))
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		m.Subscribe(sub)
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 1 }, "expected active subscription")
+		_, ok := m.Book("tBTCUSD", "P0")
+		assert.False(t, ok)
+		assert.Equal(t, 0, srv.count(`"event":"conf"`))
+	})
+}
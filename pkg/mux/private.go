@@ -0,0 +1,173 @@
+package mux
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
+)
+
+var (
+	// ErrNotAuthorized is returned by Send when private connection is not authenticated
+	ErrNotAuthorized = errors.New("not authorized")
+	// ErrReconnecting is returned by Send while private connection is being
+	// re-established after a failure. Payload is not sent and can be retried
+	ErrReconnecting = errors.New("private connection is reconnecting")
+)
+
+// AuthLost is passed to Listen callback when authenticated connection fails.
+// Mux keeps reconnecting in the background until AuthRestored is received
+type AuthLost struct {
+	Err error
+}
+
+// AuthRestored is passed to Listen callback once authenticated connection is
+// re-established and authenticated again
+type AuthRestored struct {
+	// Attempts is number of connection attempts it took to restore auth
+	Attempts int
+}
+
+const (
+	defaultReconnectMin = 1 * time.Second
+	defaultReconnectMax = 30 * time.Second
+)
+
+// WithReconnectBackoff sets delays between private connection reconnect attempts.
+// First attempt is made right away, following ones wait min, doubling up to max
+func (m *Mux) WithReconnectBackoff(min, max time.Duration) *Mux {
+	m.reconnectMin = min
+	m.reconnectMax = max
+	return m
+}
+
+// authenticatedClient returns private client if it is authenticated, typed
+// error describing why it is not available otherwise
+func (m *Mux) authenticatedClient() (*client.Client, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if m.reconnecting {
+		return nil, ErrReconnecting
+	}
+	if !m.authenticated || m.privateClient == nil {
+		return nil, ErrNotAuthorized
+	}
+	return m.privateClient, nil
+}
+
+// recordAuth handles auth event. Returns AuthRestored if event completes
+// reconnect. Must be called with mtx held
+func (m *Mux) recordAuth(cid int, i event.Info) interface{} {
+	if i.Status != "OK" {
+		if m.reconnecting {
+			log.Printf("re-authentication failed: %s\n", i.Message)
+			m.reconnectPrivate()
+		}
+		return nil
+	}
+
+	m.subInfo[chanKey{cid, i.ChanID}] = i
+	m.setSeen(chanKey{cid, i.ChanID}, time.Now())
+	m.authenticated = true
+	if !m.reconnecting {
+		return nil
+	}
+
+	m.reconnecting = false
+	return AuthRestored{Attempts: m.reconnectAttempts}
+}
+
+// privateFailed marks authenticated connection as lost and starts reconnecting.
+// Returns false if connection failed during reconnect, as loss is already reported
+func (m *Mux) privateFailed(err error) (AuthLost, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.closed() {
+		return AuthLost{}, false
+	}
+
+	reported := m.reconnecting
+	if !reported {
+		m.reconnectAttempts = 0
+	}
+	m.reconnectPrivate()
+	return AuthLost{Err: err}, !reported
+}
+
+// reconnectPrivate drops current private client and dials a new one in the
+// background, with backoff, until it succeeds or mux is closed. Dialing
+// sends auth request with a fresh nonce and dead man switch setting, auth
+// is restored once api confirms it. Must be called with mtx held
+func (m *Mux) reconnectPrivate() {
+	m.authenticated = false
+	m.reconnecting = true
+	if m.privateClient != nil {
+		if err := m.privateClient.Close(); err != nil {
+			log.Printf("failed closing private client: %s\n", err)
+		}
+		m.privateClient = nil
+	}
+
+	first := m.reconnectAttempts == 0
+	go func() {
+		delay := m.reconnectMin
+		if first {
+			delay = 0
+		}
+
+		for {
+			timer := time.NewTimer(delay)
+			select {
+			case <-m.closeChan:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			err := m.dialPrivate()
+			if err == nil {
+				return
+			}
+
+			log.Printf("private reconnect failed: %s\n", err)
+			delay = nextBackoff(delay, m.reconnectMin, m.reconnectMax)
+		}
+	}()
+}
+
+// dialPrivate makes a single reconnect attempt, dialing without mtx held
+func (m *Mux) dialPrivate() error {
+	m.mtx.Lock()
+	if m.closed() || m.privateClient != nil {
+		m.mtx.Unlock()
+		return nil
+	}
+	m.reconnectAttempts++
+	attempt := m.reconnectAttempts
+	m.mtx.Unlock()
+
+	c, err := m.dialPrivateClient()
+	if err != nil {
+		return fmt.Errorf("attempt %d: %s", attempt, err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.addPrivateClient(c)
+	return nil
+}
+
+func nextBackoff(current, min, max time.Duration) time.Duration {
+	if current < min {
+		return min
+	}
+	current *= 2
+	if current > max {
+		return max
+	}
+	return current
+}
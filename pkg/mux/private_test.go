@@ -0,0 +1,224 @@
+package mux_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authRequests returns auth requests received by the server
+func (ts *testServer) authRequests(t *testing.T) (res []map[string]interface{}) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, r := range ts.reqs {
+		if !bytes.Contains([]byte(r), []byte(`"event":"auth"`)) {
+			continue
+		}
+		var req map[string]interface{}
+		require.Nil(t, json.Unmarshal([]byte(r), &req))
+		res = append(res, req)
+	}
+	return
+}
+
+// startPrivateMux starts authenticated mux and passes auth lifecycle
+// messages and errors received by Listen to returned channel
+func startPrivateMux(t *testing.T, pubURL, authURL string) (*mux.Mux, chan interface{}) {
+	m := newMux().
+		WithPublicURL(pubURL).
+		WithAuthURL(authURL).
+		WithAPIKEY("key").
+		WithAPISEC("sec").
+		WithDeadManSwitch().
+		WithReconnectBackoff(time.Millisecond*20, time.Millisecond*80).
+		TransformRaw().
+		Start()
+	require.Nil(t, m.Err)
+
+	received := make(chan interface{}, 10)
+	go func() {
+		assert.Nil(t, m.Listen(func(ms interface{}, err error) {
+			switch ms.(type) {
+			case mux.AuthLost, mux.AuthRestored:
+				received <- ms
+			}
+			if err != nil {
+				received <- err
+			}
+		}))
+	}()
+
+	eventually(t, func() bool { return m.Send(&order.CancelRequest{ID: 1}) == nil }, "mux was not authenticated")
+	return m, received
+}
+
+func nextReceived(t *testing.T, received chan interface{}) interface{} {
+	select {
+	case ms := <-received:
+		return ms
+	case <-time.After(time.Second * 2):
+		t.Fatal("expected message was not received")
+	}
+	return nil
+}
+
+func TestPrivateReconnect(t *testing.T) {
+	t.Run("re-authenticates with fresh nonce", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+		pub, pubURL := newTestServer(t, nil)
+		auth, authURL := newTestServer(t, nil)
+
+		m, received := startPrivateMux(t, pubURL, authURL)
+
+		auth.dropConns()
+		lost, ok := nextReceived(t, received).(mux.AuthLost)
+		require.True(t, ok)
+		assert.NotNil(t, lost.Err)
+
+		restored, ok := nextReceived(t, received).(mux.AuthRestored)
+		require.True(t, ok)
+		assert.Equal(t, 1, restored.Attempts)
+		require.Nil(t, m.Send(&order.CancelRequest{ID: 2}))
+
+		reqs := auth.authRequests(t)
+		require.Len(t, reqs, 2)
+		assert.NotEqual(t, reqs[0]["authNonce"], reqs[1]["authNonce"])
+		assert.Equal(t, float64(4), reqs[1]["dms"])
+		assert.Equal(t, 1, pub.connCount())
+
+		m.Close()
+		pub.Close()
+		auth.Close()
+		waitForGoroutines(t, baseline)
+	})
+
+	t.Run("rejects send while reconnecting", func(t *testing.T) {
+		var delayAuth int32
+		pub, pubURL := newTestServer(t, nil)
+		defer pub.Close()
+		auth, authURL := newTestServer(t, func(req []byte) []string {
+			if bytes.Contains(req, []byte(`"event":"auth"`)) && atomic.LoadInt32(&delayAuth) == 1 {
+				time.Sleep(time.Millisecond * 200)
+			}
+			return respondDefault(req)
+		})
+		defer auth.Close()
+
+		m, received := startPrivateMux(t, pubURL, authURL)
+		defer m.Close()
+
+		pending := m.PendingAcks()
+		atomic.StoreInt32(&delayAuth, 1)
+		auth.dropConns()
+		_, ok := nextReceived(t, received).(mux.AuthLost)
+		require.True(t, ok)
+
+		assert.Equal(t, mux.ErrReconnecting, m.Send(&order.CancelRequest{ID: 2}))
+		assert.Equal(t, pending, m.PendingAcks())
+
+		_, ok = nextReceived(t, received).(mux.AuthRestored)
+		require.True(t, ok)
+		assert.Nil(t, m.Send(&order.CancelRequest{ID: 2}))
+	})
+
+	t.Run("retries with backoff", func(t *testing.T) {
+		pub, pubURL := newTestServer(t, nil)
+		defer pub.Close()
+		auth, authURL := newTestServer(t, nil)
+		defer auth.Close()
+
+		m, received := startPrivateMux(t, pubURL, authURL)
+		defer m.Close()
+
+		// next 3 connection attempts are refused
+		atomic.StoreInt32(&auth.reject, 3)
+		auth.dropConns()
+		_, ok := nextReceived(t, received).(mux.AuthLost)
+		require.True(t, ok)
+
+		restored, ok := nextReceived(t, received).(mux.AuthRestored)
+		require.True(t, ok)
+		assert.Equal(t, 4, restored.Attempts)
+		assert.Equal(t, 2, auth.connCount())
+		assert.Len(t, auth.authRequests(t), 2)
+	})
+
+	t.Run("reconnects when re-auth fails", func(t *testing.T) {
+		var failAuth int32
+		pub, pubURL := newTestServer(t, nil)
+		defer pub.Close()
+		auth, authURL := newTestServer(t, func(req []byte) []string {
+			if bytes.Contains(req, []byte(`"event":"auth"`)) && atomic.CompareAndSwapInt32(&failAuth, 1, 0) {
+				return []string{`{"event":"auth","status":"FAILED","chanId":0,"msg":"nonce: small","code":10114}`}
+			}
+			return respondDefault(req)
+		})
+		defer auth.Close()
+
+		m, received := startPrivateMux(t, pubURL, authURL)
+		defer m.Close()
+
+		atomic.StoreInt32(&failAuth, 1)
+		auth.dropConns()
+		_, ok := nextReceived(t, received).(mux.AuthLost)
+		require.True(t, ok)
+
+		restored, ok := nextReceived(t, received).(mux.AuthRestored)
+		require.True(t, ok)
+		assert.Equal(t, 2, restored.Attempts)
+		assert.Len(t, auth.authRequests(t), 3)
+	})
+}
+
+func TestAuthFailure(t *testing.T) {
+	pub, pubURL := newTestServer(t, nil)
+	defer pub.Close()
+	auth, authURL := newTestServer(t, func(req []byte) []string {
+		if bytes.Contains(req, []byte(`"event":"auth"`)) {
+			return []string{`{"event":"auth","status":"FAILED","chanId":0,"msg":"apikey: invalid","code":10100}`}
+		}
+		return respondDefault(req)
+	})
+	defer auth.Close()
+
+	m := newMux().
+		WithPublicURL(pubURL).
+		WithAuthURL(authURL).
+		WithAPIKEY("key").
+		WithAPISEC("sec").
+		TransformRaw().
+		Start()
+	require.Nil(t, m.Err)
+	defer m.Close()
+
+	failed := make(chan event.Info, 1)
+	go func() {
+		assert.Nil(t, m.Listen(func(ms interface{}, err error) {
+			if i, ok := ms.(event.Info); ok && i.Event == "auth" {
+				failed <- i
+			}
+		}))
+	}()
+
+	select {
+	case i := <-failed:
+		assert.Equal(t, "FAILED", i.Status)
+		assert.Equal(t, int64(10100), i.Code)
+	case <-time.After(time.Second):
+		t.Fatal("auth event was not received")
+	}
+
+	// initial auth failure is not retried, keys need fixing
+	assert.Equal(t, mux.ErrNotAuthorized, m.Send(&order.CancelRequest{ID: 1}))
+	assert.Equal(t, 1, auth.connCount())
+	assert.Equal(t, 0, m.PendingAcks())
+}
@@ -0,0 +1,273 @@
+package mux
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/msg"
+)
+
+// OverflowPolicy decides what happens to a message when route buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the route to make room, stalling Listen
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the incoming message
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest buffered message to make room
+	OverflowDropOldest
+)
+
+const defaultRouteBuffer = 256
+
+// Envelope is a message passed to routes, along with its origin
+type Envelope struct {
+	// CID is id of the client connection message came from, 0 for private one
+	CID int
+	// ChanID is channel id of data messages, 0 for events
+	ChanID int64
+	// Private is set for messages from authenticated connection
+	Private bool
+	// Info describes public channel of data messages
+	Info event.Info
+	// Type of data message, like "te", "on" or "hb", empty for snapshots and updates
+	Type string
+	// Data and Err are the same values Listen callback is called with
+	Data interface{}
+	Err  error
+}
+
+// RouteConfig describes which messages route receives and how they are buffered
+type RouteConfig struct {
+	// Match selects messages for the route, all messages if nil
+	Match func(Envelope) bool
+	// Buffer is number of messages route can queue, 256 if zero
+	Buffer   int
+	Overflow OverflowPolicy
+}
+
+// Route delivers matching messages to its consumer, independently of
+// Listen callback and other routes
+type Route struct {
+	match     func(Envelope) bool
+	overflow  OverflowPolicy
+	buf       chan Envelope
+	done      chan struct{}
+	stopOnce  sync.Once
+	closeOnce sync.Once
+	dropped   uint64
+	mux       *Mux
+}
+
+// MatchSubscription matches data of public subscription. Fields omitted in
+// sub, like precision, match any value
+func MatchSubscription(sub event.Subscribe) func(Envelope) bool {
+	return func(e Envelope) bool {
+		return !e.Private && e.ChanID != 0 && matches(sub, e.Info)
+	}
+}
+
+// MatchPrivate matches authenticated channel messages of given types,
+// like "on" or "te". Any authenticated message if types are omitted
+func MatchPrivate(types ...string) func(Envelope) bool {
+	return func(e Envelope) bool {
+		if !e.Private {
+			return false
+		}
+		if len(types) == 0 {
+			return true
+		}
+		for _, t := range types {
+			if t == e.Type {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Route registers handler called from route's own goroutine with each
+// matching message, so a slow handler does not stall other consumers
+func (m *Mux) Route(cfg RouteConfig, handler func(Envelope)) *Route {
+	r := m.addRoute(cfg)
+	go func() {
+		for {
+			select {
+			case e, ok := <-r.buf:
+				if !ok {
+					return
+				}
+				handler(e)
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// RouteChan registers route consumed through C. Channel is closed once
+// route or mux is closed
+func (m *Mux) RouteChan(cfg RouteConfig) *Route {
+	return m.addRoute(cfg)
+}
+
+// C returns channel of RouteChan route
+func (r *Route) C() <-chan Envelope {
+	return r.buf
+}
+
+// Dropped returns number of messages dropped due to overflow
+func (r *Route) Dropped() uint64 {
+	return atomic.LoadUint64(&r.dropped)
+}
+
+// Close stops delivery to the route. Subsequent calls are no-op
+func (r *Route) Close() {
+	r.stop()
+	r.closeOnce.Do(func() {
+		m := r.mux
+		m.routesMtx.Lock()
+		defer m.routesMtx.Unlock()
+		for i, v := range m.routes {
+			if v == r {
+				m.routes = append(m.routes[:i], m.routes[i+1:]...)
+				break
+			}
+		}
+		// nothing is pushed once route is removed
+		close(r.buf)
+	})
+}
+
+// stop releases push blocked on full route buffer
+func (r *Route) stop() {
+	r.stopOnce.Do(func() { close(r.done) })
+}
+
+func (m *Mux) addRoute(cfg RouteConfig) *Route {
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = defaultRouteBuffer
+	}
+
+	r := &Route{
+		match:    cfg.Match,
+		overflow: cfg.Overflow,
+		buf:      make(chan Envelope, cfg.Buffer),
+		done:     make(chan struct{}),
+		mux:      m,
+	}
+
+	m.routesMtx.Lock()
+	m.routes = append(m.routes, r)
+	m.routesMtx.Unlock()
+
+	// route added after close would never be closed otherwise
+	if m.closed() {
+		r.Close()
+	}
+	return r
+}
+
+// closeRoutes is called once mux is closed
+func (m *Mux) closeRoutes() {
+	m.routesMtx.RLock()
+	routes := append([]*Route(nil), m.routes...)
+	m.routesMtx.RUnlock()
+
+	// Listen may be blocked on any of them
+	for _, r := range routes {
+		r.stop()
+	}
+	for _, r := range routes {
+		r.Close()
+	}
+}
+
+// push must be called with routesMtx read lock held
+func (r *Route) push(e Envelope) {
+	switch r.overflow {
+	case OverflowDropNewest:
+		select {
+		case r.buf <- e:
+		default:
+			atomic.AddUint64(&r.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case r.buf <- e:
+				return
+			default:
+			}
+
+			select {
+			case <-r.buf:
+				atomic.AddUint64(&r.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case r.buf <- e:
+		case <-r.done:
+		}
+	}
+}
+
+// dispatcher passes messages received by Listen to its callback and routes
+type dispatcher struct {
+	mux *Mux
+	cb  func(interface{}, error)
+	env Envelope
+}
+
+// reset describes origin of the next message. Notices have no origin
+func (d *dispatcher) reset(ms *msg.Msg, private bool) {
+	d.env = Envelope{Private: private}
+	if ms == nil || !d.mux.hasRoutes() {
+		return
+	}
+
+	d.env.CID = ms.CID
+	d.env.Type = ms.Type()
+	if chID, ok := ms.ChanID(); ok {
+		d.env.ChanID = chID
+		if !private {
+			d.env.Info, _ = d.mux.chanInfo(ms.CID, chID)
+		}
+	}
+}
+
+// emit calls Listen callback, if any, followed by matching routes
+func (d *dispatcher) emit(data interface{}, err error) {
+	if d.cb != nil {
+		d.cb(data, err)
+	}
+	d.mux.route(d.env, data, err)
+}
+
+func (m *Mux) hasRoutes() bool {
+	m.routesMtx.RLock()
+	defer m.routesMtx.RUnlock()
+	return len(m.routes) > 0
+}
+
+// route fans message out to all matching routes
+func (m *Mux) route(e Envelope, data interface{}, err error) {
+	m.routesMtx.RLock()
+	defer m.routesMtx.RUnlock()
+	if len(m.routes) == 0 {
+		return
+	}
+
+	e.Data = data
+	e.Err = err
+	for _, r := range m.routes {
+		if r.match == nil || r.match(e) {
+			r.push(e)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mux_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nextEnvelope(t *testing.T, c <-chan mux.Envelope) mux.Envelope {
+	select {
+	case e := <-c:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("expected routed message")
+	}
+	return mux.Envelope{}
+}
+
+// chanIDs returns channel ids of active subscriptions by symbol
+func chanIDs(t *testing.T, m *mux.Mux, n int) map[string]int64 {
+	eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == n }, "expected active subscriptions")
+	res := make(map[string]int64)
+	for _, s := range m.Subscriptions() {
+		res[s.Symbol] = s.ChanID
+	}
+	return res
+}
+
+func TestRoute(t *testing.T) {
+	btc := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"}
+	eth := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tETHUSD"}
+
+	t.Run("routes subscription data to all its subscribers", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		first := m.RouteChan(mux.RouteConfig{Match: mux.MatchSubscription(btc)})
+		second := make(chan mux.Envelope, 10)
+		m.Route(mux.RouteConfig{Match: mux.MatchSubscription(btc)}, func(e mux.Envelope) { second <- e })
+		other := m.RouteChan(mux.RouteConfig{Match: mux.MatchSubscription(eth)})
+
+		m.Subscribe(btc).Subscribe(eth)
+		ids := chanIDs(t, m, 2)
+		srv.push(fmt.Sprintf(`[%d,[1,2,3]]`, ids["tETHUSD"]))
+		srv.push(fmt.Sprintf(`[%d,[4,5,6]]`, ids["tBTCUSD"]))
+
+		for _, e := range []mux.Envelope{nextEnvelope(t, first.C()), nextEnvelope(t, second)} {
+			assert.Equal(t, fmt.Sprintf(`[%d,[4,5,6]]`, ids["tBTCUSD"]), string(e.Data.([]byte)))
+			assert.Equal(t, ids["tBTCUSD"], e.ChanID)
+			assert.Equal(t, "tBTCUSD", e.Info.Symbol)
+			assert.False(t, e.Private)
+			assert.Nil(t, e.Err)
+		}
+
+		e := nextEnvelope(t, other.C())
+		assert.Equal(t, fmt.Sprintf(`[%d,[1,2,3]]`, ids["tETHUSD"]), string(e.Data.([]byte)))
+		assert.Len(t, first.C(), 0)
+		assert.Len(t, second, 0)
+	})
+
+	t.Run("routes private messages by type", func(t *testing.T) {
+		pub, pubURL := newTestServer(t, nil)
+		defer pub.Close()
+		auth, authURL := newTestServer(t, nil)
+		defer auth.Close()
+
+		m, _ := startPrivateMux(t, pubURL, authURL)
+		defer m.Close()
+
+		r := m.RouteChan(mux.RouteConfig{Match: mux.MatchPrivate("te", "tu")})
+		auth.push(`[0,"os",[]]`)
+		auth.push(`[0,"te",[1,"tBTCUSD",1568108029000,2,0.1,7000,null,null,1]]`)
+
+		e := nextEnvelope(t, r.C())
+		assert.True(t, e.Private)
+		assert.Equal(t, "te", e.Type)
+		assert.Equal(t, int64(0), e.ChanID)
+		assert.Len(t, r.C(), 0)
+	})
+
+	t.Run("by predicate", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		events := m.RouteChan(mux.RouteConfig{Match: func(e mux.Envelope) bool { return e.ChanID == 0 }})
+		m.Subscribe(btc)
+
+		e := nextEnvelope(t, events.C())
+		assert.Contains(t, string(e.Data.([]byte)), `"event":"subscribed"`)
+	})
+
+	t.Run("slow route does not stall others", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		started := make(chan struct{}, 5)
+		release := make(chan struct{})
+		var handled int32
+		slow := m.Route(mux.RouteConfig{Match: mux.MatchSubscription(btc), Buffer: 1, Overflow: mux.OverflowDropNewest}, func(mux.Envelope) {
+			started <- struct{}{}
+			<-release
+			atomic.AddInt32(&handled, 1)
+		})
+		oldest := m.RouteChan(mux.RouteConfig{Match: mux.MatchSubscription(btc), Buffer: 2, Overflow: mux.OverflowDropOldest})
+		all := m.RouteChan(mux.RouteConfig{Match: mux.MatchSubscription(btc), Buffer: 10, Overflow: mux.OverflowBlock})
+
+		m.Subscribe(btc)
+		chID := chanIDs(t, m, 1)["tBTCUSD"]
+		srv.push(fmt.Sprintf(`[%d,[0]]`, chID))
+		<-started
+		for i := 1; i < 5; i++ {
+			srv.push(fmt.Sprintf(`[%d,[%d]]`, chID, i))
+		}
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, fmt.Sprintf(`[%d,[%d]]`, chID, i), string(nextEnvelope(t, all.C()).Data.([]byte)))
+		}
+
+		// slow handler holds one message and buffers another
+		assert.Equal(t, uint64(3), slow.Dropped())
+		assert.Equal(t, uint64(3), oldest.Dropped())
+		assert.Equal(t, uint64(0), all.Dropped())
+		assert.Equal(t, fmt.Sprintf(`[%d,[3]]`, chID), string(nextEnvelope(t, oldest.C()).Data.([]byte)))
+		assert.Equal(t, fmt.Sprintf(`[%d,[4]]`, chID), string(nextEnvelope(t, oldest.C()).Data.([]byte)))
+
+		close(release)
+		eventually(t, func() bool { return atomic.LoadInt32(&handled) == 2 }, "expected slow route to catch up")
+	})
+
+	t.Run("close stops delivery", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+		baseline := runtime.NumGoroutine()
+
+		m := startMux(t, url, 30)
+		closed := m.RouteChan(mux.RouteConfig{})
+		open := m.RouteChan(mux.RouteConfig{})
+		handler := m.Route(mux.RouteConfig{}, func(mux.Envelope) {})
+
+		closed.Close()
+		closed.Close()
+		_, ok := <-closed.C()
+		assert.False(t, ok)
+
+		m.Subscribe(btc)
+		assert.Contains(t, string(nextEnvelope(t, open.C()).Data.([]byte)), `"event":"subscribed"`)
+
+		require.True(t, m.Close())
+		for range open.C() {
+		}
+		handler.Close()
+
+		// routes added after close are closed right away
+		_, ok = <-m.RouteChan(mux.RouteConfig{}).C()
+		assert.False(t, ok)
+		waitForGoroutines(t, baseline)
+	})
+}
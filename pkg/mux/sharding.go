@@ -0,0 +1,145 @@
+package mux
+
+import (
+	"sort"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
+)
+
+// Sharding decides which public connection new subscription is assigned to
+type Sharding int
+
+const (
+	// ShardFillFirst fills a connection up to subscriptions limit before
+	// opening the next one
+	ShardFillFirst Sharding = iota
+	// ShardRoundRobin spreads subscriptions evenly over number of connections
+	// set with WithConnections, opening more only once all of them are full
+	ShardRoundRobin
+	// ShardByChannel keeps each channel type on dedicated connections, so
+	// heavy books do not share connection with tickers or trades
+	ShardByChannel
+)
+
+// WithSubsLimit sets how many subscriptions a public connection takes,
+// capped at 30 which is the api limit
+func (m *Mux) WithSubsLimit(limit int) *Mux {
+	if limit > 0 && limit <= maxSubsPerConn {
+		m.subsLimit = limit
+	}
+	return m
+}
+
+// WithSharding sets strategy of assigning subscriptions to public
+// connections, ShardFillFirst by default. Subscriptions of a failed
+// connection are assigned again using the same strategy
+func (m *Mux) WithSharding(s Sharding) *Mux {
+	m.sharding = s
+	return m
+}
+
+// WithConnections sets number of public connections ShardRoundRobin
+// spreads subscriptions over, 2 by default
+func (m *Mux) WithConnections(n int) *Mux {
+	if n > 0 {
+		m.conns = n
+	}
+	return m
+}
+
+// WithPin assigns given subscriptions to connections of named shard, no
+// other subscription is assigned to. Pins take precedence over sharding
+// strategy
+func (m *Mux) WithPin(shard string, subs ...event.Subscribe) *Mux {
+	for _, sub := range subs {
+		m.pins[sub] = "pin:" + shard
+	}
+	return m
+}
+
+// shard returns name of connection group subscription belongs to
+func (m *Mux) shard(sub event.Subscribe) string {
+	if shard, ok := m.pins[sub]; ok {
+		return shard
+	}
+	if m.sharding == ShardByChannel {
+		return "channel:" + sub.Channel
+	}
+	return ""
+}
+
+// assign returns client with room for subscription, or nil client if new
+// one has to be opened. Must be called with mtx held
+func (m *Mux) assign(sub event.Subscribe) (int, *client.Client) {
+	shard := m.shard(sub)
+
+	used := make(map[int]int)
+	for _, s := range m.subs {
+		used[s.CID]++
+	}
+
+	var members, free []int
+	for cid, c := range m.publicClients {
+		if m.shards[cid] != shard {
+			continue
+		}
+		members = append(members, cid)
+		if !c.SubsLimitReached() {
+			free = append(free, cid)
+		}
+	}
+	sort.Ints(free)
+
+	if m.sharding == ShardRoundRobin && shard == "" {
+		if len(members) >= m.conns && len(free) > 0 {
+			cid := free[0]
+			for _, v := range free {
+				if v > m.current[shard] {
+					cid = v
+					break
+				}
+			}
+			m.current[shard] = cid
+			return cid, m.publicClients[cid]
+		}
+		return m.openShard(shard, used)
+	}
+
+	if cid, ok := m.current[shard]; ok {
+		if c, ok := m.publicClients[cid]; ok && m.shards[cid] == shard && !c.SubsLimitReached() {
+			return cid, c
+		}
+	}
+
+	// current client is full or gone, continue with the least used one
+	if len(free) > 0 {
+		cid := free[0]
+		for _, v := range free {
+			if used[v] < used[cid] {
+				cid = v
+			}
+		}
+		m.current[shard] = cid
+		return cid, m.publicClients[cid]
+	}
+	return m.openShard(shard, used)
+}
+
+// openShard adds empty client of any shard to given shard, returns nil client
+// if there is none. Must be called with mtx held
+func (m *Mux) openShard(shard string, used map[int]int) (int, *client.Client) {
+	empty := 0
+	for cid := range m.publicClients {
+		if used[cid] == 0 && (empty == 0 || cid < empty) {
+			empty = cid
+		}
+	}
+
+	if empty != 0 {
+		m.shards[empty] = shard
+		m.current[shard] = empty
+		return empty, m.publicClients[empty]
+	}
+	return 0, nil
+}
@@ -0,0 +1,160 @@
+package mux_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startShardedMux(t *testing.T, m *mux.Mux) *mux.Mux {
+	m.Start()
+	require.Nil(t, m.Err)
+
+	go func() {
+		assert.Nil(t, m.Listen(func(interface{}, error) {}))
+	}()
+	eventually(t, m.IsConnected, "mux did not start listening")
+	return m
+}
+
+// cidsBy returns connection ids subscriptions are assigned to, grouped by key
+func cidsBy(m *mux.Mux, key func(mux.Subscription) string) map[string]map[int]int {
+	res := make(map[string]map[int]int)
+	for _, s := range m.Subscriptions() {
+		k := key(s)
+		if res[k] == nil {
+			res[k] = make(map[int]int)
+		}
+		res[k][s.CID]++
+	}
+	return res
+}
+
+func byChannel(s mux.Subscription) string { return s.Channel }
+
+func tickers(n int) (res []event.Subscribe) {
+	for i := 0; i < n; i++ {
+		res = append(res, event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: fmt.Sprintf("tSYM%dUSD", i)})
+	}
+	return
+}
+
+func TestSharding(t *testing.T) {
+	books := []event.Subscribe{
+		{Event: "subscribe", Channel: "book", Symbol: "tBTCUSD", Precision: "P0"},
+		{Event: "subscribe", Channel: "book", Symbol: "tETHUSD", Precision: "P0"},
+	}
+
+	t.Run("fill first", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithSubsLimit(2))
+		defer m.Close()
+
+		for _, sub := range tickers(5) {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 5 }, "expected active subscriptions")
+		assert.Equal(t, map[int]int{1: 2, 2: 2, 3: 1}, cidsBy(m, byChannel)["ticker"])
+		assert.Equal(t, 3, srv.connCount())
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithSharding(mux.ShardRoundRobin).WithConnections(3))
+		defer m.Close()
+
+		for _, sub := range tickers(7) {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 7 }, "expected active subscriptions")
+		assert.Equal(t, map[int]int{1: 3, 2: 2, 3: 2}, cidsBy(m, byChannel)["ticker"])
+		assert.Equal(t, 3, srv.connCount())
+	})
+
+	t.Run("round robin opens more connections once full", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithSharding(mux.ShardRoundRobin).WithSubsLimit(1))
+		defer m.Close()
+
+		for _, sub := range tickers(3) {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 3 }, "expected active subscriptions")
+		assert.Equal(t, map[int]int{1: 1, 2: 1, 3: 1}, cidsBy(m, byChannel)["ticker"])
+	})
+
+	t.Run("by channel", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithSharding(mux.ShardByChannel).WithSubsLimit(2))
+		defer m.Close()
+
+		subs := append(tickers(3), books...)
+		for _, sub := range subs {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 5 }, "expected active subscriptions")
+
+		// initial connection is taken by the first channel type
+		cids := cidsBy(m, byChannel)
+		assert.Equal(t, map[int]int{1: 2, 2: 1}, cids["ticker"])
+		assert.Equal(t, map[int]int{3: 2}, cids["book"])
+		assert.Equal(t, 3, srv.connCount())
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		subs := tickers(3)
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithPin("btc", subs[1]))
+		defer m.Close()
+
+		for _, sub := range subs {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 3 }, "expected active subscriptions")
+
+		cids := cidsBy(m, func(s mux.Subscription) string { return s.Symbol })
+		assert.Equal(t, map[int]int{1: 1}, cids[subs[0].Symbol])
+		assert.Equal(t, map[int]int{2: 1}, cids[subs[1].Symbol])
+		assert.Equal(t, map[int]int{1: 1}, cids[subs[2].Symbol])
+	})
+
+	t.Run("reconnect keeps channel types apart", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startShardedMux(t, newMux().WithPublicURL(url).WithSharding(mux.ShardByChannel))
+		defer m.Close()
+
+		for _, sub := range append(tickers(2), books...) {
+			m.Subscribe(sub)
+		}
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 4 }, "expected active subscriptions")
+
+		srv.dropConns()
+		eventually(t, func() bool {
+			subs := subsInState(m, mux.SubscriptionActive)
+			return len(subs) == 4 && subs[0].CID > 2
+		}, "expected resubscription on new connections")
+
+		cids := cidsBy(m, byChannel)
+		require.Len(t, cids["ticker"], 1)
+		require.Len(t, cids["book"], 1)
+		for cid := range cids["ticker"] {
+			assert.NotContains(t, cids["book"], cid)
+		}
+	})
+}
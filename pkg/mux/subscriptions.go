@@ -0,0 +1,276 @@
+package mux
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux/client"
+)
+
+// SubscriptionState describes lifecycle stage of a public subscription
+type SubscriptionState string
+
+const (
+	// SubscriptionPending - subscribe request sent, waiting for api to confirm it
+	SubscriptionPending SubscriptionState = "pending"
+	// SubscriptionActive - subscription confirmed, data is flowing
+	SubscriptionActive SubscriptionState = "active"
+	// SubscriptionUnsubscribing - unsubscribe requested, waiting for api to confirm it
+	SubscriptionUnsubscribing SubscriptionState = "unsubscribing"
+)
+
+// Subscription is a point in time view of a public subscription
+type Subscription struct {
+	event.Subscribe
+	// CID is id of the client connection subscription is assigned to
+	CID int
+	// ChanID is assigned by the api once subscription is confirmed
+	ChanID int64
+	State  SubscriptionState
+	// resubscribe is set on stale channels, to subscribe again once unsubscribed
+	resubscribe bool
+}
+
+// chanKey identifies a channel, chanId is only unique per connection
+type chanKey struct {
+	cid    int
+	chanID int64
+}
+
+// Subscriptions returns view of all public subscriptions, ordered by
+// connection and channel id. Changes to the result do not affect the mux
+func (m *Mux) Subscriptions() []Subscription {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]Subscription, 0, len(m.subs))
+	for _, s := range m.subs {
+		res = append(res, *s)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].CID != res[j].CID {
+			return res[i].CID < res[j].CID
+		}
+		if res[i].ChanID != res[j].ChanID {
+			return res[i].ChanID < res[j].ChanID
+		}
+		return res[i].Channel+res[i].Symbol+res[i].Key < res[j].Channel+res[j].Symbol+res[j].Key
+	})
+	return res
+}
+
+// Unsubscribe removes subscription from its client so that it is not restored on
+// reconnect, and sends unsubscribe request to the api. Channel info is cleaned up
+// once api confirms it. Public connections left without subscriptions get closed
+func (m *Mux) Unsubscribe(sub event.Subscribe) error {
+	c, chanID, err := m.markUnsubscribing(sub)
+	if err != nil || c == nil {
+		return err
+	}
+	// request is sent without mtx held, so slow connection does not block
+	// the rest of mux
+	return c.Unsubscribe(chanID)
+}
+
+// markUnsubscribing removes subscription from its client, returning client
+// and channel to send unsubscribe request to, if it has to be sent now
+func (m *Mux) markUnsubscribing(sub event.Subscribe) (*client.Client, int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.subs[sub]
+	if !ok || (s.State == SubscriptionUnsubscribing && !s.resubscribe) {
+		return nil, 0, errors.New("not subscribed")
+	}
+
+	// stale channel is being unsubscribed already, just do not subscribe again
+	if s.resubscribe {
+		s.resubscribe = false
+		return nil, 0, nil
+	}
+
+	c, ok := m.publicClients[s.CID]
+	if !ok {
+		delete(m.subs, sub)
+		return nil, 0, nil
+	}
+
+	c.RemoveSub(sub)
+	s.State = SubscriptionUnsubscribing
+	// without chanId, unsubscribe is sent once subscription gets confirmed
+	if s.ChanID == 0 {
+		return nil, 0, nil
+	}
+	return c, s.ChanID, nil
+}
+
+// subAdded returns true if subscription is known to mux and not being removed
+func (m *Mux) subAdded(sub event.Subscribe) bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.hasSub(sub)
+}
+
+// hasSub must be called with mtx held
+func (m *Mux) hasSub(sub event.Subscribe) bool {
+	s, ok := m.subs[sub]
+	return ok && s.State != SubscriptionUnsubscribing
+}
+
+// findSub returns subscription assigned to given client and matching the event.
+// Must be called with mtx held
+func (m *Mux) findSub(cid int, i event.Info, state SubscriptionState) *Subscription {
+	for _, s := range m.subs {
+		if s.CID != cid || s.State != state {
+			continue
+		}
+		if i.ChanID != 0 && s.ChanID == i.ChanID {
+			return s
+		}
+		if s.ChanID == 0 && matches(s.Subscribe, i) {
+			return s
+		}
+	}
+	return nil
+}
+
+// recordSubscribed marks pending subscription as active, or completes
+// unsubscribe requested before confirmation. Must be called with mtx held
+func (m *Mux) recordSubscribed(cid int, i event.Info) {
+	m.subInfo[chanKey{cid, i.ChanID}] = i
+	m.setSeen(chanKey{cid, i.ChanID}, time.Now())
+
+	if s := m.findSub(cid, i, SubscriptionPending); s != nil {
+		s.ChanID = i.ChanID
+		s.State = SubscriptionActive
+		m.addBook(cid, i)
+		return
+	}
+
+	if s := m.findSub(cid, i, SubscriptionUnsubscribing); s != nil && s.ChanID == 0 {
+		s.ChanID = i.ChanID
+		if c, ok := m.publicClients[cid]; ok {
+			if err := c.Unsubscribe(i.ChanID); err != nil {
+				log.Printf("failed unsubscribing chanId %d on cid %d: %s\n", i.ChanID, cid, err)
+			}
+		}
+	}
+}
+
+// recordUnsubscribed removes subscription and its channel info, then compacts
+// public clients. Stale channels are subscribed again instead. Must be called
+// with mtx held
+func (m *Mux) recordUnsubscribed(cid int, i event.Info) {
+	delete(m.subInfo, chanKey{cid, i.ChanID})
+	m.forgetSeen(cid, i.ChanID)
+	m.forgetBooks(cid, i.ChanID)
+
+	var resubs []event.Subscribe
+	for sub, s := range m.subs {
+		// entry might have been replaced by a fresh subscribe in the meantime
+		if s.CID == cid && s.ChanID == i.ChanID && s.State == SubscriptionUnsubscribing {
+			if s.resubscribe {
+				resubs = append(resubs, sub)
+			}
+			delete(m.subs, sub)
+		}
+	}
+
+	if len(resubs) == 0 {
+		m.compact()
+		return
+	}
+
+	go func() {
+		for _, sub := range resubs {
+			log.Printf("resubscribing stale channel: %+v\n", sub)
+			m.Subscribe(sub)
+		}
+	}()
+}
+
+// recordSubscribeError drops pending subscription rejected by the api.
+// Must be called with mtx held
+func (m *Mux) recordSubscribeError(cid int, i event.Info) {
+	if i.Channel == "" {
+		return
+	}
+
+	s := m.findSub(cid, i, SubscriptionPending)
+	if s == nil {
+		return
+	}
+
+	if c, ok := m.publicClients[cid]; ok {
+		c.RemoveSub(s.Subscribe)
+	}
+	delete(m.subs, s.Subscribe)
+}
+
+// forgetClientSubs removes all subscriptions and channel info of given
+// client, returning its subscriptions. Must be called with mtx held
+func (m *Mux) forgetClientSubs(cid int) (subs []event.Subscribe) {
+	for sub, s := range m.subs {
+		if s.CID != cid {
+			continue
+		}
+		if s.State != SubscriptionUnsubscribing || s.resubscribe {
+			subs = append(subs, sub)
+		}
+		delete(m.subs, sub)
+	}
+
+	for k := range m.subInfo {
+		if k.cid == cid {
+			delete(m.subInfo, k)
+		}
+	}
+	m.forgetClientSeen(cid)
+	m.forgetBooks(cid, -1)
+	return
+}
+
+// compact closes public clients without any subscriptions, keeping at least
+// one client to accept new subscriptions. Remaining empty client can be
+// taken by any shard. Must be called with mtx held
+func (m *Mux) compact() {
+	used := make(map[int]int)
+	for _, s := range m.subs {
+		used[s.CID]++
+	}
+
+	for cid, c := range m.publicClients {
+		if len(m.publicClients) == 1 {
+			break
+		}
+		if used[cid] > 0 {
+			continue
+		}
+
+		log.Printf("closing empty public client cid: %d\n", cid)
+		if err := c.Close(); err != nil {
+			log.Printf("failed closing public client: %s\n", err)
+		}
+		delete(m.publicClients, cid)
+		delete(m.shards, cid)
+	}
+}
+
+// matches returns true if event confirms given subscription. Fields
+// omitted in subscription are filled with defaults by the api
+func matches(sub event.Subscribe, i event.Info) bool {
+	return sub.Channel == i.Channel &&
+		matchField(sub.Symbol, i.Symbol) &&
+		matchField(sub.Precision, i.Precision) &&
+		matchField(sub.Frequency, i.Frequency) &&
+		matchField(sub.Len, i.Len) &&
+		matchField(sub.Key, i.Key)
+}
+
+func matchField(requested, confirmed string) bool {
+	return requested == "" || requested == confirmed
+}
@@ -0,0 +1,179 @@
+package mux_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/event"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startMux starts mux against given server and listens in the background
+func startMux(t *testing.T, url string, subsLimit int) *mux.Mux {
+	m := newMux().WithPublicURL(url).WithSubsLimit(subsLimit).Start()
+	require.Nil(t, m.Err)
+
+	go func() {
+		assert.Nil(t, m.Listen(func(interface{}, error) {}))
+	}()
+	eventually(t, m.IsConnected, "mux did not start listening")
+	return m
+}
+
+// subsInState returns subscriptions in given state
+func subsInState(m *mux.Mux, state mux.SubscriptionState) (res []mux.Subscription) {
+	for _, s := range m.Subscriptions() {
+		if s.State == state {
+			res = append(res, s)
+		}
+	}
+	return
+}
+
+func TestSubscriptions(t *testing.T) {
+	srv, url := newTestServer(t, func(req []byte) []string {
+		// never confirm subscription to ETH
+		if bytes.Contains(req, []byte("tETHUSD")) {
+			return []string{}
+		}
+		return respondDefault(req)
+	})
+	defer srv.Close()
+
+	m := startMux(t, url, 2)
+	defer m.Close()
+
+	btc := event.Subscribe{Event: "subscribe", Channel: "book", Symbol: "tBTCUSD", Precision: "P0"}
+	eth := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tETHUSD"}
+	ltc := event.Subscribe{Event: "subscribe", Channel: "candles", Key: "trade:1m:tLTCUSD"}
+	m.Subscribe(btc).Subscribe(eth).Subscribe(ltc)
+	require.Nil(t, m.Err)
+
+	eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 2 }, "expected 2 active subscriptions")
+
+	subs := m.Subscriptions()
+	require.Len(t, subs, 3)
+
+	pending := subsInState(m, mux.SubscriptionPending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, eth, pending[0].Subscribe)
+	assert.Equal(t, 1, pending[0].CID)
+	assert.Equal(t, int64(0), pending[0].ChanID)
+
+	// subs limit is 2, so 3rd subscription lands on a new connection
+	active := subsInState(m, mux.SubscriptionActive)
+	assert.Equal(t, btc, active[0].Subscribe)
+	assert.Equal(t, 1, active[0].CID)
+	assert.NotZero(t, active[0].ChanID)
+	assert.Equal(t, ltc, active[1].Subscribe)
+	assert.Equal(t, 2, active[1].CID)
+	assert.NotZero(t, active[1].ChanID)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	t.Run("active subscription", func(t *testing.T) {
+		srv, url := newTestServer(t, nil)
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		sub := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"}
+		m.Subscribe(sub)
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 1 }, "expected active subscription")
+		chanID := m.Subscriptions()[0].ChanID
+
+		require.Nil(t, m.Unsubscribe(sub))
+		eventually(t, func() bool { return len(m.Subscriptions()) == 0 }, "expected subscription to be removed")
+		assert.Equal(t, 1, srv.count(fmt.Sprintf(`"chanId":%d`, chanID)))
+
+		// unknown subscription
+		assert.NotNil(t, m.Unsubscribe(sub))
+
+		// can subscribe again
+		m.Subscribe(sub)
+		eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 1 }, "expected to resubscribe")
+		assert.Equal(t, 2, srv.count(`"event":"subscribe"`))
+	})
+
+	t.Run("pending subscription", func(t *testing.T) {
+		srv, url := newTestServer(t, func(req []byte) []string {
+			if bytes.Contains(req, []byte(`"event":"subscribe"`)) {
+				// confirm subscription with a delay
+				time.Sleep(time.Millisecond * 100)
+			}
+			return respondDefault(req)
+		})
+		defer srv.Close()
+
+		m := startMux(t, url, 30)
+		defer m.Close()
+
+		sub := event.Subscribe{Event: "subscribe", Channel: "trades", Symbol: "tBTCUSD"}
+		m.Subscribe(sub)
+		require.Nil(t, m.Unsubscribe(sub))
+
+		subs := m.Subscriptions()
+		require.Len(t, subs, 1)
+		assert.Equal(t, mux.SubscriptionUnsubscribing, subs[0].State)
+		assert.Equal(t, 0, srv.count(`"event":"unsubscribe"`))
+
+		// unsubscribe is sent once api confirms subscription
+		eventually(t, func() bool { return len(m.Subscriptions()) == 0 }, "expected subscription to be removed")
+		assert.Equal(t, 1, srv.count(`"event":"unsubscribe"`))
+	})
+}
+
+func TestCompaction(t *testing.T) {
+	srv, url := newTestServer(t, nil)
+	defer srv.Close()
+
+	m := startMux(t, url, 2)
+	defer m.Close()
+
+	subs := []event.Subscribe{
+		{Event: "subscribe", Channel: "ticker", Symbol: "tBTCUSD"},
+		{Event: "subscribe", Channel: "ticker", Symbol: "tETHUSD"},
+		{Event: "subscribe", Channel: "ticker", Symbol: "tLTCUSD"},
+	}
+	for _, sub := range subs {
+		m.Subscribe(sub)
+	}
+	eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 3 }, "expected active subscriptions")
+	assert.Equal(t, 2, srv.connCount())
+
+	// connection 2 is left empty and gets closed
+	require.Nil(t, m.Unsubscribe(subs[2]))
+	eventually(t, func() bool { return len(m.Subscriptions()) == 2 }, "expected subscription to be removed")
+
+	// connection 1 is full, so new subscription needs a fresh connection
+	m.Subscribe(event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tXRPUSD"})
+	eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 3 }, "expected active subscriptions")
+	assert.Equal(t, 3, srv.connCount())
+
+	cids := map[int]int{}
+	for _, s := range m.Subscriptions() {
+		cids[s.CID]++
+	}
+	assert.Equal(t, map[int]int{1: 2, 3: 1}, cids)
+
+	// unsubscribing from a connection which still has subscriptions keeps it open
+	require.Nil(t, m.Unsubscribe(subs[0]))
+	eventually(t, func() bool { return len(m.Subscriptions()) == 2 }, "expected subscription to be removed")
+	eos := event.Subscribe{Event: "subscribe", Channel: "ticker", Symbol: "tEOSUSD"}
+	m.Subscribe(eos)
+	eventually(t, func() bool { return len(subsInState(m, mux.SubscriptionActive)) == 3 }, "expected active subscriptions")
+
+	cids = map[int]int{}
+	for _, s := range m.Subscriptions() {
+		cids[s.CID]++
+		if s.Subscribe == eos {
+			assert.Equal(t, 3, s.CID)
+		}
+	}
+	assert.Equal(t, map[int]int{1: 1, 3: 2}, cids)
+}
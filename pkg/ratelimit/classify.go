@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+)
+
+// Classify maps authenticated input payload to its priority and coalescing key.
+// Cancels and closes go first, updates of the same order coalesce into single
+// one, new orders and offers go last. Unknown payloads get normal priority
+func Classify(pld interface{}) (Priority, string) {
+	switch v := pld.(type) {
+	case *order.CancelRequest, order.CancelRequest,
+		*order.CancelMultiRequest, order.CancelMultiRequest,
+		*fundingoffer.CancelRequest, fundingoffer.CancelRequest,
+		*fundingloan.CancelRequest, fundingloan.CancelRequest,
+		*fundingcredit.CancelRequest, fundingcredit.CancelRequest:
+		return PriorityHigh, ""
+	case *order.UpdateRequest:
+		return PriorityNormal, updateKey(v.ID)
+	case order.UpdateRequest:
+		return PriorityNormal, updateKey(v.ID)
+	case *order.NewRequest, order.NewRequest,
+		*fundingoffer.SubmitRequest, fundingoffer.SubmitRequest:
+		return PriorityLow, ""
+	}
+	return PriorityNormal, ""
+}
+
+func updateKey(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ou:%d", id)
+}
+
+// OrderOp returns operation sending authenticated input payload with send,
+// classified by Classify. Order updates coalescing a queued one are merged
+// with it by MergeUpdates, as updates are sparse
+func OrderOp(pld interface{}, send func(pld interface{}) error) Op {
+	priority, key := Classify(pld)
+	op := Op{
+		Priority: priority,
+		Key:      key,
+		Payload:  pld,
+		Send: func() error {
+			return send(pld)
+		},
+	}
+	if key != "" {
+		op.Merge = func(queued Op) Op {
+			return OrderOp(MergeUpdates(queued.Payload, pld), send)
+		}
+	}
+	return op
+}
+
+// MergeUpdates combines queued order update with the next one of the same
+// order. Fields set by next win, fields it leaves out are kept from queued.
+// Deltas add up, unless next sets absolute amount, which supersedes them.
+// Payloads other than order updates are not merged, next is returned as is
+func MergeUpdates(queued, next interface{}) interface{} {
+	q, ok := updateRequest(queued)
+	if !ok {
+		return next
+	}
+	n, ok := updateRequest(next)
+	if !ok {
+		return next
+	}
+
+	merged := n
+	if merged.GID == 0 {
+		merged.GID = q.GID
+	}
+	if merged.Price == 0 {
+		merged.Price = q.Price
+	}
+	if merged.Amount == 0 {
+		merged.Amount = q.Amount
+		merged.Delta += q.Delta
+	}
+	if merged.Leverage == 0 {
+		merged.Leverage = q.Leverage
+	}
+	if merged.PriceTrailing == 0 {
+		merged.PriceTrailing = q.PriceTrailing
+	}
+	if merged.PriceAuxLimit == 0 {
+		merged.PriceAuxLimit = q.PriceAuxLimit
+	}
+	merged.Hidden = merged.Hidden || q.Hidden
+	merged.PostOnly = merged.PostOnly || q.PostOnly
+	if merged.TimeInForce == "" {
+		merged.TimeInForce = q.TimeInForce
+	}
+	if len(q.Meta) > 0 {
+		merged.Meta = make(map[string]interface{}, len(q.Meta)+len(n.Meta))
+		for k, v := range q.Meta {
+			merged.Meta[k] = v
+		}
+		for k, v := range n.Meta {
+			merged.Meta[k] = v
+		}
+	}
+
+	// pointer, so it is written with UpdateRequest.MarshalJSON
+	return &merged
+}
+
+func updateRequest(pld interface{}) (order.UpdateRequest, bool) {
+	switch v := pld.(type) {
+	case *order.UpdateRequest:
+		if v == nil {
+			return order.UpdateRequest{}, false
+		}
+		return *v, true
+	case order.UpdateRequest:
+		return v, true
+	}
+	return order.UpdateRequest{}, false
+}
@@ -0,0 +1,194 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	cases := map[string]struct {
+		pld      interface{}
+		priority ratelimit.Priority
+		key      string
+	}{
+		"order cancel": {
+			pld:      &order.CancelRequest{ID: 1},
+			priority: ratelimit.PriorityHigh,
+		},
+		"order cancel multi": {
+			pld:      &order.CancelMultiRequest{All: true},
+			priority: ratelimit.PriorityHigh,
+		},
+		"funding offer cancel": {
+			pld:      fundingoffer.CancelRequest{ID: 1},
+			priority: ratelimit.PriorityHigh,
+		},
+		"funding loan close": {
+			pld:      &fundingloan.CancelRequest{ID: 1},
+			priority: ratelimit.PriorityHigh,
+		},
+		"order update": {
+			pld:      &order.UpdateRequest{ID: 123},
+			priority: ratelimit.PriorityNormal,
+			key:      "ou:123",
+		},
+		"order update without id": {
+			pld:      order.UpdateRequest{},
+			priority: ratelimit.PriorityNormal,
+		},
+		"new order": {
+			pld:      &order.NewRequest{CID: 1},
+			priority: ratelimit.PriorityLow,
+		},
+		"new funding offer": {
+			pld:      &fundingoffer.SubmitRequest{},
+			priority: ratelimit.PriorityLow,
+		},
+		"unknown payload": {
+			pld:      map[string]string{"foo": "bar"},
+			priority: ratelimit.PriorityNormal,
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			p, key := ratelimit.Classify(v.pld)
+			assert.Equal(t, v.priority, p)
+			assert.Equal(t, v.key, key)
+		})
+	}
+}
+
+func TestMergeUpdates(t *testing.T) {
+	cases := map[string]struct {
+		queued   interface{}
+		next     interface{}
+		expected interface{}
+	}{
+		"deltas add up": {
+			queued:   &order.UpdateRequest{ID: 1, Delta: 1},
+			next:     &order.UpdateRequest{ID: 1, Delta: 1},
+			expected: &order.UpdateRequest{ID: 1, Delta: 2},
+		},
+		"price then amount": {
+			queued:   &order.UpdateRequest{ID: 1, Price: 9000},
+			next:     &order.UpdateRequest{ID: 1, Amount: 0.5},
+			expected: &order.UpdateRequest{ID: 1, Price: 9000, Amount: 0.5},
+		},
+		"next fields win": {
+			queued:   &order.UpdateRequest{ID: 1, Price: 9000, Leverage: 10, TimeInForce: "2020-01-01 10:45:23"},
+			next:     &order.UpdateRequest{ID: 1, Price: 9100, Hidden: true},
+			expected: &order.UpdateRequest{ID: 1, Price: 9100, Leverage: 10, Hidden: true, TimeInForce: "2020-01-01 10:45:23"},
+		},
+		"amount supersedes queued delta": {
+			queued:   &order.UpdateRequest{ID: 1, Delta: 1},
+			next:     &order.UpdateRequest{ID: 1, Amount: 3},
+			expected: &order.UpdateRequest{ID: 1, Amount: 3},
+		},
+		"delta after amount": {
+			queued:   &order.UpdateRequest{ID: 1, Amount: 3},
+			next:     &order.UpdateRequest{ID: 1, Delta: -1},
+			expected: &order.UpdateRequest{ID: 1, Amount: 3, Delta: -1},
+		},
+		"meta is merged": {
+			queued:   &order.UpdateRequest{ID: 1, Meta: map[string]interface{}{"aff_code": "abc", "lev": 5}},
+			next:     &order.UpdateRequest{ID: 1, Meta: map[string]interface{}{"lev": 10}},
+			expected: &order.UpdateRequest{ID: 1, Meta: map[string]interface{}{"aff_code": "abc", "lev": 10}},
+		},
+		"values become pointer": {
+			queued:   order.UpdateRequest{ID: 1, Delta: 1},
+			next:     order.UpdateRequest{ID: 1, Price: 9000},
+			expected: &order.UpdateRequest{ID: 1, Price: 9000, Delta: 1},
+		},
+		"other payloads are not merged": {
+			queued:   &order.CancelRequest{ID: 1},
+			next:     &order.UpdateRequest{ID: 1, Delta: 1},
+			expected: &order.UpdateRequest{ID: 1, Delta: 1},
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, v.expected, ratelimit.MergeUpdates(v.queued, v.next))
+		})
+	}
+}
+
+// waitForCoalesced blocks until governor has coalesced given number of operations
+func waitForCoalesced(t *testing.T, g *ratelimit.Governor, coalesced int64) {
+	for i := 0; i < 100; i++ {
+		if g.Stats().Coalesced == coalesced {
+			return
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatalf("coalesced count %d never reached, got: %d", coalesced, g.Stats().Coalesced)
+}
+
+func TestOrderOp(t *testing.T) {
+	// queues updates behind the one which used up the limit, returns what
+	// got sent and errors of every submit
+	submit := func(t *testing.T, updates ...*order.UpdateRequest) ([]interface{}, []error) {
+		g := ratelimit.New(1, time.Millisecond*100)
+		defer g.Close()
+
+		var mtx sync.Mutex
+		sent := []interface{}{}
+		send := func(pld interface{}) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			sent = append(sent, pld)
+			return nil
+		}
+
+		first := &order.NewRequest{CID: 1}
+		require.Nil(t, g.Submit(context.Background(), ratelimit.OrderOp(first, send)))
+
+		errs := make([]error, len(updates))
+		orders := map[int64]bool{}
+		var wg sync.WaitGroup
+		for i, u := range updates {
+			wg.Add(1)
+			go func(i int, u *order.UpdateRequest) {
+				defer wg.Done()
+				errs[i] = g.Submit(context.Background(), ratelimit.OrderOp(u, send))
+			}(i, u)
+			// updates of the same order take single place in queue
+			orders[u.ID] = true
+			waitForDepth(t, g, len(orders))
+			waitForCoalesced(t, g, int64(i+1-len(orders)))
+		}
+		wg.Wait()
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		return sent[1:], errs
+	}
+
+	t.Run("queued deltas are summed", func(t *testing.T) {
+		sent, errs := submit(t, &order.UpdateRequest{ID: 1, Delta: 1}, &order.UpdateRequest{ID: 1, Delta: 1})
+		assert.Equal(t, []interface{}{&order.UpdateRequest{ID: 1, Delta: 2}}, sent)
+		assert.Equal(t, []error{ratelimit.ErrCoalesced, nil}, errs)
+	})
+
+	t.Run("queued price is kept with amount", func(t *testing.T) {
+		sent, errs := submit(t, &order.UpdateRequest{ID: 1, Price: 9000}, &order.UpdateRequest{ID: 1, Amount: 0.5})
+		assert.Equal(t, []interface{}{&order.UpdateRequest{ID: 1, Price: 9000, Amount: 0.5}}, sent)
+		assert.Equal(t, []error{ratelimit.ErrCoalesced, nil}, errs)
+	})
+
+	t.Run("updates of other orders are not merged", func(t *testing.T) {
+		sent, errs := submit(t, &order.UpdateRequest{ID: 1, Delta: 1}, &order.UpdateRequest{ID: 2, Delta: 1})
+		assert.Len(t, sent, 2)
+		assert.Equal(t, []error{nil, nil}, errs)
+	})
+}
@@ -0,0 +1,277 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Priority defines the order in which queued operations are sent. Operations
+// with higher priority are always sent first, operations with equal priority
+// are sent in the order they were submitted
+type Priority int
+
+const (
+	// PriorityLow - meant for new orders and offers
+	PriorityLow Priority = iota
+	// PriorityNormal - meant for order updates and generic messages
+	PriorityNormal
+	// PriorityHigh - meant for cancels, which free up exposure
+	PriorityHigh
+)
+
+var (
+	// ErrCoalesced is returned to the submitter of an operation which got
+	// replaced by, or merged into, newer operation with the same key before
+	// being sent
+	ErrCoalesced = errors.New("operation superseded by a newer one with the same key")
+	// ErrClosed is returned for operations submitted to, or queued in, closed governor
+	ErrClosed = errors.New("rate limit governor closed")
+)
+
+// Op describes a single rate limited operation
+type Op struct {
+	Priority Priority
+	// Key, if set, coalesces operations: when an operation with the same key
+	// is still queued, it is replaced by the new one, keeping its queue position.
+	// See Merge to combine them instead
+	Key string
+	// Send performs the actual operation once the rate limit allows it
+	Send func() error
+	// Payload is the message Send writes, passed to Merge of a newer operation
+	Payload interface{}
+	// Merge, if set, is called when the operation coalesces a queued one, and
+	// returns the operation to queue instead, e.g. combining both payloads
+	Merge func(queued Op) Op
+}
+
+// Stats holds a point in time view of governor queue
+type Stats struct {
+	QueueDepth int
+	Sent       int64
+	Coalesced  int64
+	LastWait   time.Duration
+	MaxWait    time.Duration
+	AvgWait    time.Duration
+}
+
+type item struct {
+	op       Op
+	seq      uint64
+	enqueued time.Time
+	done     chan error
+}
+
+// Governor queues operations and sends them at most limit times per interval.
+// Safe for concurrent use and meant to be shared by everything writing to a
+// single socket
+type Governor struct {
+	mtx       sync.Mutex
+	limit     int
+	interval  time.Duration
+	sent      []time.Time
+	queue     []*item
+	keys      map[string]*item
+	seq       uint64
+	stats     Stats
+	totalWait time.Duration
+	wake      chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// New returns pointer to Governor which allows limit operations per interval
+func New(limit int, interval time.Duration) *Governor {
+	if limit < 1 {
+		limit = 1
+	}
+
+	g := &Governor{
+		limit:    limit,
+		interval: interval,
+		sent:     make([]time.Time, 0, limit),
+		queue:    make([]*item, 0),
+		keys:     make(map[string]*item),
+		wake:     make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+
+	go g.run()
+	return g
+}
+
+// Submit queues the operation and blocks until it is sent, coalesced, the
+// governor is closed or ctx is done. Returns the error of op.Send if sent
+func (g *Governor) Submit(ctx context.Context, op Op) error {
+	g.mtx.Lock()
+	select {
+	case <-g.closed:
+		g.mtx.Unlock()
+		return ErrClosed
+	default:
+	}
+
+	it := &item{op: op, done: make(chan error, 1)}
+	if old, ok := g.keys[op.Key]; ok && op.Key != "" {
+		if op.Merge != nil {
+			it.op = op.Merge(old.op)
+		}
+		// take over position of the queued operation
+		it.seq = old.seq
+		it.enqueued = old.enqueued
+		g.replace(old, it)
+		g.stats.Coalesced++
+		old.done <- ErrCoalesced
+	} else {
+		g.seq++
+		it.seq = g.seq
+		it.enqueued = time.Now()
+		g.queue = append(g.queue, it)
+	}
+
+	if op.Key != "" {
+		g.keys[op.Key] = it
+	}
+	g.stats.QueueDepth = len(g.queue)
+	g.mtx.Unlock()
+	g.notify()
+
+	select {
+	case err := <-it.done:
+		return err
+	case <-ctx.Done():
+		g.mtx.Lock()
+		g.remove(it)
+		g.mtx.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats returns current queue depth and wait time statistics
+func (g *Governor) Stats() Stats {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.stats
+}
+
+// Close stops the governor. All queued operations are released with ErrClosed
+func (g *Governor) Close() {
+	g.closeOnce.Do(func() {
+		g.mtx.Lock()
+		defer g.mtx.Unlock()
+
+		close(g.closed)
+		for _, it := range g.queue {
+			it.done <- ErrClosed
+		}
+		g.queue = g.queue[:0]
+		g.keys = make(map[string]*item)
+		g.stats.QueueDepth = 0
+	})
+}
+
+func (g *Governor) run() {
+	for {
+		g.mtx.Lock()
+		it := g.next()
+		if it == nil {
+			g.mtx.Unlock()
+			select {
+			case <-g.wake:
+				continue
+			case <-g.closed:
+				return
+			}
+		}
+
+		now := time.Now()
+		if wait := g.delay(now); wait > 0 {
+			g.mtx.Unlock()
+			t := time.NewTimer(wait)
+			select {
+			case <-t.C:
+			case <-g.wake:
+				t.Stop()
+			case <-g.closed:
+				t.Stop()
+				return
+			}
+			continue
+		}
+
+		g.remove(it)
+		g.sent = append(g.sent, now)
+		g.recordWait(now.Sub(it.enqueued))
+		g.mtx.Unlock()
+
+		it.done <- it.op.Send()
+	}
+}
+
+// next returns queued item with highest priority and lowest sequence number
+func (g *Governor) next() *item {
+	var res *item
+	for _, it := range g.queue {
+		if res == nil ||
+			it.op.Priority > res.op.Priority ||
+			(it.op.Priority == res.op.Priority && it.seq < res.seq) {
+			res = it
+		}
+	}
+	return res
+}
+
+// delay returns how long to wait until the next operation can be sent
+func (g *Governor) delay(now time.Time) time.Duration {
+	cutoff := now.Add(-g.interval)
+	i := 0
+	for i < len(g.sent) && !g.sent[i].After(cutoff) {
+		i++
+	}
+	g.sent = g.sent[i:]
+
+	if len(g.sent) < g.limit {
+		return 0
+	}
+	return g.sent[0].Add(g.interval).Sub(now)
+}
+
+func (g *Governor) recordWait(wait time.Duration) {
+	g.stats.Sent++
+	g.stats.LastWait = wait
+	if wait > g.stats.MaxWait {
+		g.stats.MaxWait = wait
+	}
+	g.totalWait += wait
+	g.stats.AvgWait = g.totalWait / time.Duration(g.stats.Sent)
+}
+
+func (g *Governor) replace(old, it *item) {
+	for i, v := range g.queue {
+		if v == old {
+			g.queue[i] = it
+			return
+		}
+	}
+}
+
+func (g *Governor) remove(it *item) {
+	for i, v := range g.queue {
+		if v == it {
+			g.queue = append(g.queue[:i], g.queue[i+1:]...)
+			break
+		}
+	}
+	if g.keys[it.op.Key] == it {
+		delete(g.keys, it.op.Key)
+	}
+	g.stats.QueueDepth = len(g.queue)
+}
+
+func (g *Governor) notify() {
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,155 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mtx  sync.Mutex
+	sent []string
+}
+
+func (r *recorder) op(p ratelimit.Priority, key, name string) ratelimit.Op {
+	return ratelimit.Op{
+		Priority: p,
+		Key:      key,
+		Send: func() error {
+			r.mtx.Lock()
+			defer r.mtx.Unlock()
+			r.sent = append(r.sent, name)
+			return nil
+		},
+	}
+}
+
+func (r *recorder) all() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]string{}, r.sent...)
+}
+
+// waitForDepth blocks until governor has given number of operations queued
+func waitForDepth(t *testing.T, g *ratelimit.Governor, depth int) {
+	for i := 0; i < 100; i++ {
+		if g.Stats().QueueDepth == depth {
+			return
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatalf("queue depth %d never reached, got: %d", depth, g.Stats().QueueDepth)
+}
+
+func TestSubmit(t *testing.T) {
+	t.Run("returns send error", func(t *testing.T) {
+		g := ratelimit.New(10, time.Second)
+		defer g.Close()
+
+		expected := errors.New("boom")
+		err := g.Submit(context.Background(), ratelimit.Op{Send: func() error { return expected }})
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("sends higher priority first", func(t *testing.T) {
+		g := ratelimit.New(1, time.Millisecond*100)
+		defer g.Close()
+		r := &recorder{}
+
+		// use up the limit so that following ops get queued
+		require.Nil(t, g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "first")))
+
+		var wg sync.WaitGroup
+		submit := func(op ratelimit.Op, depth int) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Nil(t, g.Submit(context.Background(), op))
+			}()
+			waitForDepth(t, g, depth)
+		}
+
+		submit(r.op(ratelimit.PriorityLow, "", "new order"), 1)
+		submit(r.op(ratelimit.PriorityNormal, "", "update"), 2)
+		submit(r.op(ratelimit.PriorityHigh, "", "cancel"), 3)
+		wg.Wait()
+
+		assert.Equal(t, []string{"first", "cancel", "update", "new order"}, r.all())
+	})
+
+	t.Run("coalesces queued ops with the same key", func(t *testing.T) {
+		g := ratelimit.New(1, time.Millisecond*100)
+		defer g.Close()
+		r := &recorder{}
+
+		require.Nil(t, g.Submit(context.Background(), r.op(ratelimit.PriorityNormal, "ou:1", "first")))
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- g.Submit(context.Background(), r.op(ratelimit.PriorityNormal, "ou:1", "stale"))
+		}()
+		waitForDepth(t, g, 1)
+
+		err := g.Submit(context.Background(), r.op(ratelimit.PriorityNormal, "ou:1", "latest"))
+		require.Nil(t, err)
+		assert.Equal(t, ratelimit.ErrCoalesced, <-errs)
+		assert.Equal(t, []string{"first", "latest"}, r.all())
+		assert.Equal(t, int64(1), g.Stats().Coalesced)
+	})
+
+	t.Run("removes op from queue when context is done", func(t *testing.T) {
+		g := ratelimit.New(1, time.Second)
+		defer g.Close()
+		r := &recorder{}
+
+		require.Nil(t, g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "first")))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+		err := g.Submit(ctx, r.op(ratelimit.PriorityLow, "", "second"))
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, 0, g.Stats().QueueDepth)
+		assert.Equal(t, []string{"first"}, r.all())
+	})
+
+	t.Run("enforces the limit and records wait times", func(t *testing.T) {
+		interval := time.Millisecond * 50
+		g := ratelimit.New(2, interval)
+		defer g.Close()
+		r := &recorder{}
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			require.Nil(t, g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "op")))
+		}
+
+		assert.True(t, time.Since(start) >= interval)
+		stats := g.Stats()
+		assert.Equal(t, int64(3), stats.Sent)
+		assert.True(t, stats.MaxWait >= interval/2)
+		assert.Equal(t, stats.MaxWait, stats.LastWait)
+	})
+
+	t.Run("releases queued ops on close", func(t *testing.T) {
+		g := ratelimit.New(1, time.Second)
+		r := &recorder{}
+
+		require.Nil(t, g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "first")))
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "second"))
+		}()
+		waitForDepth(t, g, 1)
+
+		g.Close()
+		assert.Equal(t, ratelimit.ErrClosed, <-errs)
+		assert.Equal(t, ratelimit.ErrClosed, g.Submit(context.Background(), r.op(ratelimit.PriorityLow, "", "third")))
+	})
+}
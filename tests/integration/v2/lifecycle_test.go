@@ -0,0 +1,360 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/websocket"
+	gorilla "github.com/gorilla/websocket"
+)
+
+// waitForGoroutines fails the test if number of running goroutines does not
+// drop back to given baseline. Listener timeout goroutines live for 2 seconds
+func waitForGoroutines(t *testing.T, baseline int) {
+	for i := 0; i < 150; i++ {
+		if runtime.NumGoroutine() <= baseline {
+			return
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+	buf := make([]byte, 1<<16)
+	n := runtime.Stack(buf, true)
+	t.Fatalf("leaked goroutines, expected %d got %d:\n%s", baseline, runtime.NumGoroutine(), buf[:n])
+}
+
+func TestRunReturnsOnCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	async := newTestAsync()
+	ws := websocket.NewWithAsyncFactoryNonce(newTestAsyncFactory(async), &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ws.Run(ctx)
+	}()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second * 2):
+		t.Fatal("Run did not return after context was cancelled")
+	}
+
+	// calling Close after Run is a no-op
+	ws.Close()
+	waitForGoroutines(t, baseline)
+}
+
+func TestRunDrainsOrderAcks(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.ShutdownTimeout = time.Second * 2
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(async), &IncrementingNonceGenerator{}).
+		Credentials("apiKeyABC", "apiSecretXYZ")
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ws.Run(ctx)
+	}()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+	async.Publish(`{"event":"auth","status":"OK","chanId":0,"userId":1,"subId":"nonce1","auth_id":"valid-auth-guid","caps":{"orders":{"read":1,"write":1},"account":{"read":1,"write":0},"funding":{"read":1,"write":1},"history":{"read":1,"write":0},"wallets":{"read":1,"write":0},"withdraw":{"read":0,"write":0},"positions":{"read":1,"write":0}}}`)
+	if _, err := listener.nextAuthEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ws.SubmitOrder(context.Background(), &order.NewRequest{CID: 788, Symbol: "tBTCUSD"}); err != nil {
+		t.Fatal(err)
+	}
+	assert(t, 1, ws.PendingAcks())
+
+	// order is still in flight, so client keeps running
+	cancel()
+	select {
+	case <-errs:
+		t.Fatal("Run returned before in-flight order was acknowledged")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	async.Publish(`[0,"n",[null,"on-req",null,null,[1201469553,0,788,"tBTCUSD",1611922089073,1611922089073,0.001,0.001,"EXCHANGE LIMIT",null,null,null,0,"ACTIVE",null,null,33,0,0,0,null,null,null,0,0,null,null,null,"API>BFX",null,null,null],null,"SUCCESS","Submitting exchange limit buy order for 0.001 BTC."]]`)
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after order was acknowledged")
+	}
+
+	select {
+	case n := <-listener.notifications:
+		assert(t, "on-req", n.Type)
+	default:
+		t.Fatal("expected order notification to be delivered")
+	}
+	assert(t, 0, ws.PendingAcks())
+	waitForGoroutines(t, baseline)
+}
+
+func TestRunDrainTimeout(t *testing.T) {
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.ShutdownTimeout = time.Millisecond * 100
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(async), &IncrementingNonceGenerator{}).
+		Credentials("apiKeyABC", "apiSecretXYZ")
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ws.Run(ctx)
+	}()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+	async.Publish(`{"event":"auth","status":"OK","chanId":0,"userId":1,"subId":"nonce1","auth_id":"valid-auth-guid","caps":{"orders":{"read":1,"write":1},"account":{"read":1,"write":0},"funding":{"read":1,"write":1},"history":{"read":1,"write":0},"wallets":{"read":1,"write":0},"withdraw":{"read":0,"write":0},"positions":{"read":1,"write":0}}}`)
+	if _, err := listener.nextAuthEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ws.SubmitCancel(context.Background(), &order.CancelRequest{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// acknowledgement never arrives, client gives up after ShutdownTimeout
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second * 2):
+		t.Fatal("Run did not return after ShutdownTimeout")
+	}
+	if elapsed := time.Since(start); elapsed < params.ShutdownTimeout {
+		t.Fatalf("expected Run to wait for ShutdownTimeout, took: %s", elapsed)
+	}
+	assert(t, 1, ws.PendingAcks())
+}
+
+func TestCloseWithStalledListener(t *testing.T) {
+	t.Run("consumer stops reading", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+
+		async := newTestAsync()
+		ws := websocket.NewWithAsyncFactoryNonce(newTestAsyncFactory(async), &IncrementingNonceGenerator{})
+		if err := ws.Connect(); err != nil {
+			t.Fatal(err)
+		}
+
+		msgs := ws.Listen()
+		async.Publish(`{"event":"info","version":2}`)
+		select {
+		case <-msgs:
+		case <-time.After(time.Second):
+			t.Fatal("expected info event")
+		}
+
+		// nobody reads this one, it stays in flight
+		async.Publish(`{"event":"conf","status":"OK","flags":65536}`)
+
+		closed := make(chan struct{})
+		go func() {
+			ws.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(time.Second * 2):
+			t.Fatal("Close blocked on message nobody reads")
+		}
+		waitForGoroutines(t, baseline)
+	})
+
+	t.Run("consumer closes from listen loop", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+
+		async := newTestAsync()
+		ws := websocket.NewWithAsyncFactoryNonce(newTestAsyncFactory(async), &IncrementingNonceGenerator{})
+		if err := ws.Connect(); err != nil {
+			t.Fatal(err)
+		}
+
+		received, inFlight := make(chan struct{}), make(chan struct{})
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for range ws.Listen() {
+				close(received)
+				<-inFlight
+				ws.Close()
+			}
+		}()
+
+		async.Publish(`{"event":"info","version":2}`)
+		<-received
+		// consumer is busy, this one stays in flight while it closes the client
+		async.Publish(`{"event":"conf","status":"OK","flags":65536}`)
+		close(inFlight)
+
+		select {
+		case <-closed:
+		case <-time.After(time.Second * 2):
+			t.Fatal("Close called from listen loop did not return")
+		}
+		waitForGoroutines(t, baseline)
+	})
+}
+
+// drop fails test connection the way a peer going away does, which makes
+// client reconnect
+func drop(async *TestAsync) {
+	async.done <- &gorilla.CloseError{Code: gorilla.CloseAbnormalClosure}
+}
+
+// refusedAsync is a connection which can not be established
+type refusedAsync struct {
+	*TestAsync
+}
+
+func (r *refusedAsync) Connect() error {
+	return errors.New("connection refused")
+}
+
+// reconnectFactory returns given connection first, then the ones created by
+// next, so reconnect attempts can be driven by the test
+type reconnectFactory struct {
+	first   websocket.Asynchronous
+	next    func() websocket.Asynchronous
+	created int32
+}
+
+func (f *reconnectFactory) Create() websocket.Asynchronous {
+	if atomic.AddInt32(&f.created, 1) == 1 {
+		return f.first
+	}
+	return f.next()
+}
+
+func TestCloseDuringReconnectBackoff(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.ReconnectInterval = time.Minute
+	factory := &reconnectFactory{
+		first: async,
+		next:  func() websocket.Asynchronous { return newTestAsync() },
+	}
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, factory, &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	// client waits for reconnect interval once connection is dropped
+	drop(async)
+	for i := 0; ws.IsConnected(); i++ {
+		if i == 100 {
+			t.Fatal("expected client to disconnect")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		ws.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second * 2):
+		t.Fatal("Close blocked on reconnect backoff")
+	}
+
+	if created := atomic.LoadInt32(&factory.created); created != 1 {
+		t.Fatalf("expected no reconnect after close, got %d connections", created)
+	}
+	waitForGoroutines(t, baseline)
+}
+
+func TestRunReturnsWhenReconnectFails(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.ReconnectInterval = time.Millisecond * 10
+	params.ReconnectAttempts = 2
+	factory := &reconnectFactory{
+		first: async,
+		next:  func() websocket.Asynchronous { return &refusedAsync{newTestAsync()} },
+	}
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, factory, &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ws.Run(context.Background())
+	}()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	drop(async)
+	select {
+	case err := <-errs:
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Fatalf("expected reconnect error, got: %v", err)
+		}
+	case <-time.After(time.Second * 2):
+		t.Fatal("Run did not return after reconnect attempts ran out")
+	}
+
+	if created := atomic.LoadInt32(&factory.created); created != 3 {
+		t.Fatalf("expected 2 reconnect attempts, got %d", created-1)
+	}
+	waitForGoroutines(t, baseline)
+}
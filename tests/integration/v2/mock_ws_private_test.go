@@ -1,10 +1,15 @@
 package tests
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/balanceinfo"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/filter"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/wallet"
 	"github.com/bitfinexcom/bitfinex-api-go/v2/websocket"
 )
@@ -61,6 +66,109 @@ func TestAuthentication(t *testing.T) {
 	assert(t, expected2.ChanID, actual2.ChanID)
 }
 
+func TestAuthenticationFilter(t *testing.T) {
+	// create transport & nonce mocks
+	async := newTestAsync()
+	nonce := &IncrementingNonceGenerator{}
+
+	// create client
+	filters := filter.New().Trading("tBTCUSD").WalletCurrency("exchange", "USD").Build()
+	ws := websocket.NewWithAsyncFactoryNonce(newTestAsyncFactory(async), nonce).
+		Credentials("apiKeyABC", "apiSecretXYZ").
+		AuthFilter(filters)
+
+	// setup listener
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	// set ws options
+	err_ws := ws.Connect()
+	if err_ws != nil {
+		t.Fatal(err_ws)
+	}
+	defer ws.Close()
+
+	// begin test
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert outgoing auth request carries filters
+	if err := async.waitForMessage(0); err != nil {
+		t.Fatal(err.Error())
+	}
+	actual := *async.Sent[0].(*websocket.SubscriptionRequest)
+	assert(t, "auth", actual.Event)
+	assert(t, fmt.Sprint([]string{"trading-tBTCUSD", "wallet-exchange-USD"}), fmt.Sprint(actual.Filter))
+}
+
+func TestOrderRateLimit(t *testing.T) {
+	// create transport & nonce mocks
+	async := newTestAsync()
+	nonce := &IncrementingNonceGenerator{}
+
+	// create client allowing single order operation per 200ms
+	params := websocket.NewDefaultParameters()
+	params.OrderRateLimit = 1
+	params.OrderRateInterval = time.Millisecond * 200
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(async), nonce).
+		Credentials("apiKeyABC", "apiSecretXYZ")
+
+	// setup listener
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	err_ws := ws.Connect()
+	if err_ws != nil {
+		t.Fatal(err_ws)
+	}
+	defer ws.Close()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+	async.Publish(`{"event":"auth","status":"OK","chanId":0,"userId":1,"subId":"nonce1","auth_id":"valid-auth-guid","caps":{"orders":{"read":1,"write":1},"account":{"read":1,"write":0},"funding":{"read":1,"write":1},"history":{"read":1,"write":0},"wallets":{"read":1,"write":0},"withdraw":{"read":0,"write":0},"positions":{"read":1,"write":0}}}`)
+	if _, err := listener.nextAuthEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	// first order uses up the limit, the rest gets queued
+	if err := ws.SubmitOrder(context.Background(), &order.NewRequest{CID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	send := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	send(func() error { return ws.SubmitOrder(context.Background(), &order.NewRequest{CID: 2}) })
+	time.Sleep(time.Millisecond * 20)
+	send(func() error { return ws.SubmitCancel(context.Background(), &order.CancelRequest{ID: 5}) })
+	wg.Wait()
+
+	// auth request + 3 order operations
+	if err := async.waitForMessage(3); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := async.Sent[2].(*order.CancelRequest); !ok {
+		t.Fatalf("expected cancel to be sent before queued new order, got: %#v", async.Sent[2])
+	}
+	if nr, ok := async.Sent[3].(*order.NewRequest); !ok || nr.CID != 2 {
+		t.Fatalf("expected queued new order last, got: %#v", async.Sent[3])
+	}
+	if stats := ws.OrderQueueStats(); stats.Sent != 3 || stats.QueueDepth != 0 {
+		t.Fatalf("unexpected queue stats: %+v", stats)
+	}
+}
+
 func TestWalletBalanceUpdates(t *testing.T) {
 	// create transport & nonce mocks
 	async := newTestAsync()
@@ -2,7 +2,10 @@ package tests
 
 import (
 	"context"
+	"fmt"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ticker"
@@ -228,3 +231,138 @@ func TestOrderbook(t *testing.T) {
 // 		t.Fatal("Expected socket count to be 6 but got", conCount)
 // 	}
 // }
+
+func TestPlanSubscriptions(t *testing.T) {
+	params := websocket.NewDefaultParameters()
+	params.CapacityPerConnection = 10
+	params.SubscribeRateLimit = 5
+	params.SubscribeRateInterval = time.Second
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(newTestAsync()), &IncrementingNonceGenerator{}).
+		Credentials("apiKeyABC", "apiSecretXYZ")
+
+	reqs := make([]*websocket.SubscriptionRequest, 0)
+	for i := 0; i < 24; i++ {
+		reqs = append(reqs, &websocket.SubscriptionRequest{
+			Event:   websocket.EventSubscribe,
+			Channel: websocket.ChanTicker,
+			Symbol:  fmt.Sprintf("tSYM%dUSD", i),
+		})
+	}
+	// duplicate of already planned subscription
+	reqs = append(reqs, &websocket.SubscriptionRequest{
+		Event:   websocket.EventSubscribe,
+		Channel: websocket.ChanTicker,
+		Symbol:  "tSYM0USD",
+	})
+
+	plan := ws.PlanSubscriptions(reqs)
+	assert(t, 24, plan.Subscriptions)
+	assert(t, 3, plan.Sockets)
+	assert(t, fmt.Sprint([]int{10, 9, 6}), fmt.Sprint(plan.PerSocket))
+	assert(t, time.Second*4, plan.EstimatedDuration)
+	// planning does not connect
+	assert(t, 0, ws.ConnectionCount())
+}
+
+func TestSubscribeRateLimit(t *testing.T) {
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.SubscribeRateLimit = 1
+	params.SubscribeRateInterval = time.Millisecond * 100
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(async), &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	start := time.Now()
+	for _, symbol := range []string{"tBTCUSD", "tETHUSD", "tLTCUSD"} {
+		if _, err := ws.SubscribeTicker(context.Background(), symbol); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < params.SubscribeRateInterval*2 {
+		t.Fatalf("expected subscriptions to be spread over time, took: %s", elapsed)
+	}
+	assert(t, 3, async.SentCount())
+}
+
+func TestSubscribeChannelLimitRetry(t *testing.T) {
+	async := newTestAsync()
+	params := websocket.NewDefaultParameters()
+	params.SubscribeRetryInterval = time.Millisecond * 10
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, newTestAsyncFactory(async), &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ws.SubscribeTicker(context.Background(), "tBTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// socket rejects subscription as it has too many channels open
+	async.Publish(`{"event":"error","msg":"subscribe: limit","code":10305,"subId":"nonce1","channel":"ticker","symbol":"tBTCUSD"}`)
+
+	// subscription is moved to a freshly opened socket
+	for i := 0; i < 50 && ws.ConnectionCount() < 2; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert(t, 2, ws.ConnectionCount())
+
+	sub, err := ws.LookupSubscription(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "tBTCUSD", sub.Symbol)
+}
+
+func TestSubscribeChannelLimitRetryAfterClose(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	async := newTestAsync()
+	factory := newTestAsyncFactory(async).(*TestAsyncFactory)
+	params := websocket.NewDefaultParameters()
+	params.SubscribeRetryInterval = time.Millisecond * 200
+	ws := websocket.NewWithParamsAsyncFactoryNonce(params, factory, &IncrementingNonceGenerator{})
+
+	listener := newListener()
+	listener.run(ws.Listen())
+
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	async.Publish(`{"event":"info","version":2}`)
+	if _, err := listener.nextInfoEvent(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ws.SubscribeTicker(context.Background(), "tBTCUSD"); err != nil {
+		t.Fatal(err)
+	}
+	async.Publish(`{"event":"error","msg":"subscribe: limit","code":10305,"subId":"nonce1","channel":"ticker","symbol":"tBTCUSD"}`)
+	// next message is read only once the error, and so the retry, is handled
+	async.Publish(`{"event":"conf","status":"OK","flags":65536}`)
+
+	// client is closed while the retry waits, so no new socket gets opened
+	ws.Close()
+	time.Sleep(params.SubscribeRetryInterval * 2)
+	assert(t, 1, factory.Count)
+	waitForGoroutines(t, baseline)
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/account"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+// AccountService manages account info endpoints
+type AccountService struct {
+	requestFactory
+	Synchronous
+}
+
+// UserInfo - retrieves info of the account api key belongs to
+// see https://docs.bitfinex.com/reference#rest-auth-info-user for more info
+func (s *AccountService) UserInfo() (*account.UserInfo, error) {
+	req, err := s.requestFactory.NewAuthenticatedRequest(common.PermissionRead, "info/user")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.UserInfoFromRaw(raw)
+}
+
+// Summary - retrieves 30 day trading volume and fee tier of the account
+// see https://docs.bitfinex.com/reference#rest-auth-summary for more info
+func (s *AccountService) Summary() (*account.Summary, error) {
+	req, err := s.requestFactory.NewAuthenticatedRequest(common.PermissionRead, "summary")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.SummaryFromRaw(raw)
+}
+
+// Permissions - retrieves scopes the api key in use has read and write access to
+// see https://docs.bitfinex.com/reference#key-permissions for more info
+func (s *AccountService) Permissions() (*account.Permissions, error) {
+	req, err := s.requestFactory.NewAuthenticatedRequest(common.PermissionRead, "permissions")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.PermissionsFromRaw(raw)
+}
+
+// Require - checks api key has the given access to all of the scopes, meant
+// to be called once on startup, e.g. with common.PermissionWrite and scopes
+// used by a trading service. Returns error listing missing scopes, or
+// error without calling the api if t is neither read nor write
+func (s *AccountService) Require(t common.PermissionType, scopes ...account.Scope) error {
+	if err := account.CheckPermissionType(t); err != nil {
+		return err
+	}
+
+	p, err := s.Permissions()
+	if err != nil {
+		return err
+	}
+
+	missing, err := p.Missing(t, scopes...)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("api key lacks %q access to scopes: %v", t, missing)
+	}
+	return nil
+}
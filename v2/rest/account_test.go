@@ -0,0 +1,122 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/account"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountUserInfo(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/info/user", r.RequestURI)
+		assert.Equal(t, "POST", r.Method)
+
+		respMock := []interface{}{
+			1234567, "user@example.com", "user", 1580401111000, 1, 3, nil,
+			"Europe/Zurich", "en_US", nil, 1, nil, "sub", nil, 1580401111000, 0,
+		}
+		payload, _ := json.Marshal(respMock)
+		_, err := w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	u, err := c.Account.UserInfo()
+	require.Nil(t, err)
+	assert.Equal(t, int64(1234567), u.ID)
+	assert.Equal(t, "Europe/Zurich", u.Timezone)
+	assert.True(t, u.EmailVerified)
+	assert.True(t, u.SubAccount())
+}
+
+func TestAccountSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/summary", r.RequestURI)
+
+		_, err := w.Write([]byte(`[null,null,null,null,[[0.001,0.001,0.001,null,null,-0.0002],[0.002,0.002,0.002,null,null,0.00065]],[{"curr":"Total (USD)","vol":52000.25,"vol_maker":12000}],null,null,null,{"leo_lev":1,"leo_amount_avg":10.5}]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	s, err := c.Account.Summary()
+	require.Nil(t, err)
+	assert.Equal(t, 0.001, s.Fees.MakerFee)
+	assert.Equal(t, 0.00065, s.Fees.DerivTakerFee)
+	assert.Equal(t, 52000.25, s.TotalVolume())
+	assert.Equal(t, int64(1), s.LeoLevel)
+}
+
+func TestAccountPermissions(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/permissions", r.RequestURI)
+
+		_, err := w.Write([]byte(`[["account",1,0],["history",1,0],["orders",1,1],["positions",1,0],["wallets",1,1]]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+
+	t.Run("permissions", func(t *testing.T) {
+		p, err := c.Account.Permissions()
+		require.Nil(t, err)
+		require.Len(t, p.Permissions, 5)
+		ok, err := p.Allows(account.ScopeOrders, common.PermissionWrite)
+		require.Nil(t, err)
+		assert.True(t, ok)
+		ok, err = p.Allows(account.ScopePositions, common.PermissionWrite)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("require granted scopes", func(t *testing.T) {
+		err := c.Account.Require(common.PermissionWrite, account.ScopeOrders, account.ScopeWallets)
+		assert.Nil(t, err)
+	})
+
+	t.Run("require missing scopes", func(t *testing.T) {
+		err := c.Account.Require(common.PermissionWrite, account.ScopeOrders, account.ScopePositions, account.ScopeFunding)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "positions")
+		assert.Contains(t, err.Error(), "funding")
+		assert.NotContains(t, err.Error(), "orders")
+	})
+
+	t.Run("require unsupported permission type", func(t *testing.T) {
+		unused := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s", r.RequestURI)
+		}))
+		defer unused.Close()
+
+		err := rest.NewClientWithURL(unused.URL).Account.Require(common.PermissionCalc, account.ScopeOrders)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unsupported permission type")
+		assert.NotContains(t, err.Error(), "lacks")
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`["error",10100,"apikey: invalid"]`))
+		}))
+		defer failing.Close()
+
+		err := rest.NewClientWithURL(failing.URL).Account.Require(common.PermissionWrite, account.ScopeOrders)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/alert"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+// AlertsService manages server side alerts endpoints
+type AlertsService struct {
+	requestFactory
+	Synchronous
+}
+
+// List - retrieves all of the alerts of the given type
+// see https://docs.bitfinex.com/reference#rest-auth-alert-list for more info
+func (s *AlertsService) List(t alert.Type) (*alert.Snapshot, error) {
+	payload := map[string]interface{}{"type": t}
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, "alerts", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return alert.SnapshotFromRaw(raw)
+}
+
+// SetPrice - creates alert triggered when symbol reaches the given price
+// see https://docs.bitfinex.com/reference#rest-auth-alert-set for more info
+func (s *AlertsService) SetPrice(symbol string, price float64) (*alert.Alert, error) {
+	if err := validPriceAlert(symbol, price); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"type":   alert.TypePrice,
+		"symbol": symbol,
+		"price":  price,
+	}
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionWrite, "alert/set", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return alert.FromRaw(raw)
+}
+
+// DeletePrice - deletes price alert of the given symbol and price. Returns
+// false when api did not delete the alert
+// see https://docs.bitfinex.com/reference#rest-auth-alert-del for more info
+func (s *AlertsService) DeletePrice(symbol string, price float64) (bool, error) {
+	if err := validPriceAlert(symbol, price); err != nil {
+		return false, err
+	}
+
+	key := alert.Key(alert.TypePrice, symbol, price)
+	req, err := s.requestFactory.NewAuthenticatedRequest(common.PermissionWrite, "alert/"+key+"/del")
+	if err != nil {
+		return false, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return false, err
+	}
+
+	if len(raw) == 0 {
+		return false, fmt.Errorf("data slice too short for alert delete: %#v", raw)
+	}
+	return convert.BValOrFalse(raw[0]), nil
+}
+
+func validPriceAlert(symbol string, price float64) error {
+	if symbol == "" {
+		return errors.New("alert symbol is required")
+	}
+	if price <= 0 {
+		return fmt.Errorf("alert price has to be positive, got: %v", price)
+	}
+	return nil
+}
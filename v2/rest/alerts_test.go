@@ -0,0 +1,122 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/alert"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertsList(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/alerts", r.RequestURI)
+		assert.Equal(t, "POST", r.Method)
+
+		gotReqPld := map[string]string{}
+		err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]string{"type": "price"}, gotReqPld)
+
+		_, err = w.Write([]byte(`[["price:tBTCUSD:560.92","price","tBTCUSD",560.92,91],["price:tETHUSD:200","price","tETHUSD",200,100]]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	s, err := c.Alerts.List(alert.TypePrice)
+	require.Nil(t, err)
+
+	expected := []*alert.Alert{
+		{Key: "price:tBTCUSD:560.92", Type: alert.TypePrice, Symbol: "tBTCUSD", Price: 560.92},
+		{Key: "price:tETHUSD:200", Type: alert.TypePrice, Symbol: "tETHUSD", Price: 200},
+	}
+	assert.Equal(t, expected, s.Snapshot)
+}
+
+func TestAlertsSetPrice(t *testing.T) {
+	t.Run("invalid arguments", func(t *testing.T) {
+		c := rest.NewClient()
+		a, err := c.Alerts.SetPrice("", 600)
+		require.NotNil(t, err)
+		require.Nil(t, a)
+
+		a, err = c.Alerts.SetPrice("tBTCUSD", 0)
+		require.NotNil(t, err)
+		require.Nil(t, a)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/w/alert/set", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			gotReqPld := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			expectedReqPld := map[string]interface{}{"type": "price", "symbol": "tBTCUSD", "price": 600.5}
+			assert.Equal(t, expectedReqPld, gotReqPld)
+
+			_, err = w.Write([]byte(`["price:tBTCUSD:600.5","price","tBTCUSD",600.5,100]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		a, err := c.Alerts.SetPrice("tBTCUSD", 600.5)
+		require.Nil(t, err)
+
+		expected := &alert.Alert{Key: "price:tBTCUSD:600.5", Type: alert.TypePrice, Symbol: "tBTCUSD", Price: 600.5}
+		assert.Equal(t, expected, a)
+	})
+}
+
+func TestAlertsDeletePrice(t *testing.T) {
+	t.Run("invalid arguments", func(t *testing.T) {
+		c := rest.NewClient()
+		ok, err := c.Alerts.DeletePrice("tBTCUSD", -1)
+		require.NotNil(t, err)
+		assert.False(t, ok)
+	})
+
+	cases := map[string]struct {
+		resp     string
+		expected bool
+		err      bool
+	}{
+		"deleted":     {resp: `[true]`, expected: true},
+		"not deleted": {resp: `[false]`},
+		"empty":       {resp: `[]`, err: true},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/auth/w/alert/price:tBTCUSD:560.92/del", r.RequestURI)
+				assert.Equal(t, "POST", r.Method)
+				_, err := w.Write([]byte(v.resp))
+				require.Nil(t, err)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			c := rest.NewClientWithURL(server.URL)
+			ok, err := c.Alerts.DeletePrice("tBTCUSD", 560.92)
+			if v.err {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, v.expected, ok)
+		})
+	}
+}
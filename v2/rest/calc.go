@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+// CalcService manages calculation endpoints
+type CalcService struct {
+	requestFactory
+	Synchronous
+}
+
+// AvailableBalance - calculates maximum amount of an order of the given
+// symbol, direction, price, balance type and leverage, given current wallet
+// and margin state
+// see https://docs.bitfinex.com/reference#rest-auth-calc-order-avail for more info
+func (s *CalcService) AvailableBalance(r *calc.AvailableBalanceRequest) (*calc.AvailableBalance, error) {
+	if err := validAvailableBalance(r); err != nil {
+		return nil, err
+	}
+
+	bytes, err := r.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionCalc, "order/avail", bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return calc.AvailableBalanceFromRaw(r, raw)
+}
+
+// AvailableFunding - calculates maximum amount of a funding offer of the given
+// funding currency symbol
+// see https://docs.bitfinex.com/reference#rest-auth-calc-order-avail for more info
+func (s *CalcService) AvailableFunding(symbol string) (*calc.AvailableBalance, error) {
+	return s.AvailableBalance(&calc.AvailableBalanceRequest{
+		Symbol: symbol,
+		Dir:    calc.Buy,
+		Type:   calc.BalanceFunding,
+	})
+}
+
+func validAvailableBalance(r *calc.AvailableBalanceRequest) error {
+	if r == nil {
+		return errors.New("available balance request is required")
+	}
+
+	switch r.Type {
+	case calc.BalanceExchange, calc.BalanceMargin, calc.BalanceDeriv:
+		if !strings.HasPrefix(r.Symbol, common.TradingPrefix) {
+			return fmt.Errorf("invalid trading pair symbol: %q", r.Symbol)
+		}
+	case calc.BalanceFunding:
+		if !strings.HasPrefix(r.Symbol, common.FundingPrefix) {
+			return fmt.Errorf("invalid funding currency symbol: %q", r.Symbol)
+		}
+	default:
+		return fmt.Errorf("invalid balance type: %q", r.Type)
+	}
+
+	switch {
+	case r.Dir != calc.Buy && r.Dir != calc.Sell:
+		return fmt.Errorf("invalid direction: %d", r.Dir)
+	case r.Rate < 0:
+		return errors.New("rate can not be negative")
+	case r.Lev != 0 && r.Type != calc.BalanceDeriv:
+		return errors.New("leverage applies to derivatives only")
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package rest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/calc"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailableBalance(t *testing.T) {
+	t.Run("invalid requests", func(t *testing.T) {
+		c := rest.NewClient()
+		cases := map[string]*calc.AvailableBalanceRequest{
+			"nil request":          nil,
+			"unknown type":         {Symbol: "tBTCUSD", Dir: calc.Buy, Type: "SPOT"},
+			"funding symbol":       {Symbol: "fUSD", Dir: calc.Buy, Type: calc.BalanceExchange},
+			"trading symbol":       {Symbol: "tBTCUSD", Dir: calc.Buy, Type: calc.BalanceFunding},
+			"missing direction":    {Symbol: "tBTCUSD", Type: calc.BalanceExchange},
+			"negative rate":        {Symbol: "tBTCUSD", Dir: calc.Buy, Rate: -1, Type: calc.BalanceExchange},
+			"leverage on exchange": {Symbol: "tBTCUSD", Dir: calc.Buy, Type: calc.BalanceExchange, Lev: 10},
+		}
+
+		for k, r := range cases {
+			got, err := c.Calc.AvailableBalance(r)
+			assert.NotNil(t, err, k)
+			assert.Nil(t, got, k)
+		}
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/calc/order/avail", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			got, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+			assert.JSONEq(t, `{"symbol":"tBTCF0:USTF0","dir":-1,"rate":"34000.5","type":"DERIV","lev":"10"}`, string(got))
+
+			_, err = w.Write([]byte(`[-2.5]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		got, err := c.Calc.AvailableBalance(&calc.AvailableBalanceRequest{
+			Symbol: "tBTCF0:USTF0",
+			Dir:    calc.Sell,
+			Rate:   34000.5,
+			Type:   calc.BalanceDeriv,
+			Lev:    10,
+		})
+		require.Nil(t, err)
+
+		expected := &calc.AvailableBalance{
+			Symbol: "tBTCF0:USTF0",
+			Dir:    calc.Sell,
+			Type:   calc.BalanceDeriv,
+			Amount: -2.5,
+		}
+		assert.Equal(t, expected, got)
+		assert.Equal(t, 2.5, got.Size())
+	})
+}
+
+func TestAvailableFunding(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/calc/order/avail", r.RequestURI)
+
+		got, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"symbol":"fUSD","dir":1,"type":"FUNDING"}`, string(got))
+
+		_, err = w.Write([]byte(`[1500.75]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Calc.AvailableFunding("fUSD")
+	require.Nil(t, err)
+	assert.Equal(t, 1500.75, got.Amount)
+	assert.Equal(t, calc.BalanceFunding, got.Type)
+}
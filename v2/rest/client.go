@@ -52,6 +52,13 @@ type Client struct {
 	Pulse          PulseService
 	Invoice        InvoiceService
 	Market         MarketService
+	Movements      MovementService
+	Alerts         AlertsService
+	Margin         MarginService
+	Calc           CalcService
+	Account        AccountService
+	Liquidations   LiquidationsService
+	Rankings       RankingsService
 
 	Synchronous
 }
@@ -127,6 +134,13 @@ func NewClientWithSynchronousURLNonce(sync Synchronous, url string, nonce utils.
 	c.Pulse = PulseService{Synchronous: c, requestFactory: c}
 	c.Invoice = InvoiceService{Synchronous: c, requestFactory: c}
 	c.Market = MarketService{Synchronous: c, requestFactory: c}
+	c.Movements = MovementService{Synchronous: c, requestFactory: c}
+	c.Alerts = AlertsService{Synchronous: c, requestFactory: c}
+	c.Margin = MarginService{Synchronous: c, requestFactory: c}
+	c.Calc = CalcService{Synchronous: c, requestFactory: c}
+	c.Account = AccountService{Synchronous: c, requestFactory: c}
+	c.Liquidations = LiquidationsService{Synchronous: c, requestFactory: c}
+	c.Rankings = RankingsService{Synchronous: c, requestFactory: c}
 	return c
 }
 
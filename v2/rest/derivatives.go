@@ -1,12 +1,15 @@
 package rest
 
 import (
+	"errors"
 	"path"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/derivatives"
 )
 
-// OrderService manages data flow for the Order API endpoint
+// DerivativesService manages derivative positions collateral endpoints
 type DerivativesService struct {
 	requestFactory
 	Synchronous
@@ -20,7 +23,7 @@ func (s *WalletService) SetCollateral(symbol string, amount float64) (bool, erro
 		"symbol":     symbol,
 		"collateral": amount,
 	}
-	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, urlPath, data)
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionWrite, urlPath, data)
 	if err != nil {
 		return false, err
 	}
@@ -32,10 +35,31 @@ func (s *WalletService) SetCollateral(symbol string, amount float64) (bool, erro
 	if len(raw) <= 0 {
 		return false, nil
 	}
-	item := raw[0].([]interface{})
+	item, _ := raw[0].([]interface{})
 	// [1] == success, [] || [0] == false
-	if len(item) > 0 && item[0].(int) == 1 {
+	if len(item) > 0 && convert.I64ValOrZero(item[0]) == 1 {
 		return true, nil
 	}
 	return false, nil
 }
+
+// CollateralLimits - retrieves minimum and maximum collateral derivative
+// position of the given symbol can be set to
+// see https://docs.bitfinex.com/reference#rest-auth-calc-deriv-collateral-limits for more info
+func (s *DerivativesService) CollateralLimits(symbol string) (*derivatives.CollateralLimits, error) {
+	if symbol == "" {
+		return nil, errors.New("derivative symbol is required")
+	}
+
+	urlPath := path.Join("deriv", "collateral", "limits")
+	data := map[string]interface{}{"symbol": symbol}
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionCalc, urlPath, data)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return derivatives.CollateralLimitsFromRaw(symbol, raw)
+}
@@ -0,0 +1,90 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/derivatives"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCollateral(t *testing.T) {
+	cases := map[string]struct {
+		resp     string
+		expected bool
+	}{
+		"success":        {resp: `[[1]]`, expected: true},
+		"float success":  {resp: `[[1.0]]`, expected: true}, // json numbers decode as float64
+		"failure":        {resp: `[[0]]`},
+		"empty":          {resp: `[]`},
+		"empty item":     {resp: `[[]]`},
+		"malformed item": {resp: `[1]`},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				// collateral is changed, so write permission path is used
+				assert.Equal(t, "/auth/w/deriv/collateral/set", r.RequestURI)
+
+				gotReqPld := map[string]interface{}{}
+				err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+				require.Nil(t, err)
+				assert.Equal(t, map[string]interface{}{"symbol": "tBTCF0:USTF0", "collateral": 50.5}, gotReqPld)
+
+				_, err = w.Write([]byte(v.resp))
+				require.Nil(t, err)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(handler))
+			defer server.Close()
+
+			c := rest.NewClientWithURL(server.URL)
+			ok, err := c.Wallet.SetCollateral("tBTCF0:USTF0", 50.5)
+			require.Nil(t, err)
+			assert.Equal(t, v.expected, ok)
+		})
+	}
+}
+
+func TestCollateralLimits(t *testing.T) {
+	t.Run("missing symbol", func(t *testing.T) {
+		c := rest.NewClient()
+		cl, err := c.Derivatives.CollateralLimits("")
+		require.NotNil(t, err)
+		require.Nil(t, cl)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/calc/deriv/collateral/limits", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			gotReqPld := map[string]string{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			assert.Equal(t, map[string]string{"symbol": "tBTCF0:USTF0"}, gotReqPld)
+
+			_, err = w.Write([]byte(`[0.5,120.25]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		cl, err := c.Derivatives.CollateralLimits("tBTCF0:USTF0")
+		require.Nil(t, err)
+
+		expected := &derivatives.CollateralLimits{
+			Symbol:        "tBTCF0:USTF0",
+			MinCollateral: 0.5,
+			MaxCollateral: 120.25,
+		}
+		assert.Equal(t, expected, cl)
+	})
+}
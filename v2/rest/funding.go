@@ -2,11 +2,13 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingcredit"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundinginfo"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingtrade"
@@ -190,6 +192,97 @@ func (fs *FundingService) CancelOffer(fc *fundingoffer.CancelRequest) (*notifica
 	return notification.FromRaw(raw)
 }
 
+// CancelAllOffers - submits a request to cancel all funding offers of the
+// given currency, or of all currencies when currency is empty
+// see https://docs.bitfinex.com/reference#rest-auth-cancel-all-funding-offers for more info
+func (fs *FundingService) CancelAllOffers(fc *fundingoffer.CancelAllRequest) (*notification.Notification, error) {
+	if fc == nil {
+		fc = &fundingoffer.CancelAllRequest{}
+	}
+	bytes, err := fc.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	req, err := fs.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionWrite, "funding/offer/cancel/all", bytes)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := fs.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return notification.FromRaw(raw)
+}
+
+// CloseFunding - submits a request to close funding loan or credit with the given id
+// see https://docs.bitfinex.com/reference#rest-auth-funding-close for more info
+func (fs *FundingService) CloseFunding(fc *fundingloan.CancelRequest) (*notification.Notification, error) {
+	if fc == nil || fc.ID == 0 {
+		return nil, errors.New("funding id is required")
+	}
+	bytes, err := fc.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	req, err := fs.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionWrite, "funding/close", bytes)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := fs.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return notification.FromRaw(raw)
+}
+
+// AutoRenew - turns funding auto-renew of the given currency on or off. Notify
+// info of returned notification is a *fundingloan.AutoRenew
+// see https://docs.bitfinex.com/reference#rest-auth-funding-auto-renew for more info
+func (fs *FundingService) AutoRenew(ar *fundingloan.AutoRenewRequest) (*notification.Notification, error) {
+	switch {
+	case ar == nil || ar.Currency == "":
+		return nil, errors.New("auto-renew currency is required")
+	case ar.Period != 0 && (ar.Period < 2 || ar.Period > 120):
+		return nil, fmt.Errorf("auto-renew period has to be between 2 and 120 days, got: %d", ar.Period)
+	case ar.Amount < 0 || ar.Rate < 0:
+		return nil, errors.New("auto-renew amount and rate can not be negative")
+	}
+	bytes, err := ar.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	req, err := fs.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionWrite, "funding/auto", bytes)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := fs.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return notification.FromRaw(raw)
+}
+
+// AutoRenewStatus - retrieves funding auto-renew configuration of the given
+// currency. Returns nil when auto-renew is off
+// see https://docs.bitfinex.com/reference#rest-auth-funding-auto-renew-status for more info
+func (fs *FundingService) AutoRenewStatus(currency string) (*fundingloan.AutoRenew, error) {
+	if currency == "" {
+		return nil, errors.New("auto-renew currency is required")
+	}
+	req, err := fs.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, "funding/auto/status", map[string]interface{}{"currency": currency})
+	if err != nil {
+		return nil, err
+	}
+	raw, err := fs.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	return fundingloan.AutoRenewFromRaw(raw)
+}
+
 // KeepFunding - toggle to keep funding taken. Specify loan for unused funding and credit for used funding.
 // see https://docs.bitfinex.com/reference#rest-auth-keep-funding for more info
 func (fs *FundingService) KeepFunding(args KeepFundingRequest) (*notification.Notification, error) {
@@ -218,3 +311,17 @@ func (fs *FundingService) KeepFunding(args KeepFundingRequest) (*notification.No
 
 	return notification.FromRaw(raw)
 }
+
+// Info - retrieves funding info of the given funding currency
+// see https://docs.bitfinex.com/reference#rest-auth-info-funding for more info
+func (fs *FundingService) Info(symbol string) (*fundinginfo.FundingInfo, error) {
+	req, err := fs.requestFactory.NewAuthenticatedRequest(common.PermissionRead, path.Join("info/funding", symbol))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := fs.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return fundinginfo.FromRaw(raw)
+}
@@ -2,10 +2,14 @@ package rest_test
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundinginfo"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
 	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,3 +51,136 @@ func TestKeepFunding(t *testing.T) {
 		assert.Equal(t, int64(1568711312683), rsp.MTS)
 	})
 }
+
+func TestFundingInfo(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/info/funding/fUSD", r.RequestURI)
+		assert.Equal(t, "POST", r.Method)
+
+		_, err := w.Write([]byte(`["sym","fUSD",[0.0008595462068208099,0,1.8444560185185186,0]]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Funding.Info("fUSD")
+	require.Nil(t, err)
+
+	expected := &fundinginfo.FundingInfo{
+		Symbol:       "fUSD",
+		YieldLoan:    0.0008595462068208099,
+		YieldLend:    0,
+		DurationLoan: 1.8444560185185186,
+		DurationLend: 0,
+	}
+	assert.Equal(t, expected, got)
+}
+
+// fundingServer checks request uri and payload and responds with resp
+func fundingServer(t *testing.T, uri, pld, resp string) *httptest.Server {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		assert.Equal(t, "POST", r.Method)
+
+		got, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+		assert.JSONEq(t, pld, string(got))
+
+		_, err = w.Write([]byte(resp))
+		require.Nil(t, err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(handler))
+}
+
+func TestCancelAllOffers(t *testing.T) {
+	server := fundingServer(t,
+		"/auth/w/funding/offer/cancel/all",
+		`{"currency":"USD"}`,
+		`[1611922089,"foc_all-req",null,null,null,null,"SUCCESS","None to cancel"]`,
+	)
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	n, err := c.Funding.CancelAllOffers(&fundingoffer.CancelAllRequest{Currency: "USD"})
+	require.Nil(t, err)
+	assert.Equal(t, "foc_all-req", n.Type)
+	assert.Equal(t, "SUCCESS", n.Status)
+}
+
+func TestCloseFunding(t *testing.T) {
+	t.Run("missing id", func(t *testing.T) {
+		c := rest.NewClient()
+		n, err := c.Funding.CloseFunding(&fundingloan.CancelRequest{})
+		require.NotNil(t, err)
+		require.Nil(t, n)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		server := fundingServer(t,
+			"/auth/w/funding/close",
+			`{"id":2994}`,
+			`[1611922089,"fcl-req",null,null,null,null,"SUCCESS","Funding closed"]`,
+		)
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		n, err := c.Funding.CloseFunding(&fundingloan.CancelRequest{ID: 2994})
+		require.Nil(t, err)
+		assert.Equal(t, "Funding closed", n.Text)
+	})
+}
+
+func TestAutoRenew(t *testing.T) {
+	t.Run("invalid requests", func(t *testing.T) {
+		c := rest.NewClient()
+		for _, ar := range []*fundingloan.AutoRenewRequest{
+			nil,
+			{Status: true},
+			{Status: true, Currency: "USD", Period: 121},
+			{Status: true, Currency: "USD", Rate: -1},
+		} {
+			n, err := c.Funding.AutoRenew(ar)
+			require.NotNil(t, err)
+			require.Nil(t, n)
+		}
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		server := fundingServer(t,
+			"/auth/w/funding/auto",
+			`{"status":1,"currency":"USD","amount":"500","rate":"0.02","period":2}`,
+			`[1611922089,"fa-req",null,null,["USD",2,0.02,500],null,"SUCCESS","auto-renew activated"]`,
+		)
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		n, err := c.Funding.AutoRenew(&fundingloan.AutoRenewRequest{Status: true, Currency: "USD", Amount: 500, Rate: 0.02, Period: 2})
+		require.Nil(t, err)
+		assert.Equal(t, &fundingloan.AutoRenew{Currency: "USD", Period: 2, Rate: 0.02, Threshold: 500}, n.NotifyInfo)
+	})
+}
+
+func TestAutoRenewStatus(t *testing.T) {
+	t.Run("active", func(t *testing.T) {
+		server := fundingServer(t, "/auth/r/funding/auto/status", `{"currency":"USD"}`, `["USD",2,0.02,500]`)
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		ar, err := c.Funding.AutoRenewStatus("USD")
+		require.Nil(t, err)
+		assert.Equal(t, &fundingloan.AutoRenew{Currency: "USD", Period: 2, Rate: 0.02, Threshold: 500}, ar)
+	})
+
+	t.Run("inactive", func(t *testing.T) {
+		server := fundingServer(t, "/auth/r/funding/auto/status", `{"currency":"USD"}`, `null`)
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		ar, err := c.Funding.AutoRenewStatus("USD")
+		require.Nil(t, err)
+		assert.Nil(t, ar)
+	})
+}
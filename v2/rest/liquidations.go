@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/status"
+)
+
+const maxLiquidationsLimit = 500
+
+// LiquidationsService manages the Liquidations endpoint.
+type LiquidationsService struct {
+	requestFactory
+	Synchronous
+}
+
+// History - retrieves liquidations of the whole platform within the given
+// time window. Zero values are left to api defaults
+// see https://docs.bitfinex.com/reference#rest-public-liquidations for more info
+func (s *LiquidationsService) History(
+	start common.Mts,
+	end common.Mts,
+	limit common.QueryLimit,
+	sort common.SortOrder,
+) (*status.LiquidationsSnapshot, error) {
+	if limit > maxLiquidationsLimit {
+		return nil, fmt.Errorf("max request limit is %d, got: %d", maxLiquidationsLimit, limit)
+	}
+
+	req := NewRequestWithMethod("liquidations/hist", "GET")
+	req.Params = Page{Start: int64(start), End: int64(end), Limit: int32(limit)}.params(sort)
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return status.LiqHistoryFromRaw(raw)
+}
+
+// AllHistory - retrieves liquidations page by page, newest first, until start
+// of the page is reached. Page limit is a size of every single request
+func (s *LiquidationsService) AllHistory(p Page) ([]*status.Liquidation, error) {
+	ls := make([]*status.Liquidation, 0)
+	pages := NewPaginator(p)
+	for pages.Next() {
+		pg := pages.Page()
+		snap, err := s.History(common.Mts(pg.Start), common.Mts(pg.End), common.QueryLimit(pg.Limit), common.NewestFirst)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range snap.Snapshot {
+			if pages.Keep(l.PositionID, l.MTS) {
+				ls = append(ls, l)
+			}
+		}
+	}
+
+	return ls, nil
+}
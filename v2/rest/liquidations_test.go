@@ -0,0 +1,132 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/status"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawLiquidation(id, mts int64) []interface{} {
+	return []interface{}{
+		[]interface{}{"pos", id, mts, nil, "tETHF0:USTF0", -1.67288094, 730.96, nil, 1, 1, nil, 736.13},
+	}
+}
+
+// pageQuery reads page of public history request
+func pageQuery(t *testing.T, r *http.Request) rest.Page {
+	q := r.URL.Query()
+	p := rest.Page{}
+	if v := q.Get("start"); v != "" {
+		p.Start, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := q.Get("end"); v != "" {
+		p.End, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.ParseInt(v, 10, 32)
+		require.Nil(t, err)
+		p.Limit = int32(l)
+	}
+	return p
+}
+
+func TestLiquidationsHistory(t *testing.T) {
+	t.Run("limit too high", func(t *testing.T) {
+		c := rest.NewClient()
+		s, err := c.Liquidations.History(0, 0, 501, common.NewestFirst)
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("calls correct resource with correct params", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/liquidations/hist?end=1609144400000&limit=2&sort=1&start=1609144300000", r.RequestURI)
+			assert.Equal(t, "GET", r.Method)
+
+			respMock := []interface{}{rawLiquidation(145400868, 1609144352338), rawLiquidation(145400869, 1609144352339)}
+			payload, _ := json.Marshal(respMock)
+			_, err := w.Write(payload)
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		s, err := c.Liquidations.History(1609144300000, 1609144400000, 2, common.OldestFirst)
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+
+		expected := &status.Liquidation{
+			Symbol:        "tETHF0:USTF0",
+			PositionID:    145400868,
+			MTS:           1609144352338,
+			Amount:        -1.67288094,
+			BasePrice:     730.96,
+			IsMatch:       1,
+			IsMarketSold:  1,
+			PriceAcquired: 736.13,
+		}
+		assert.Equal(t, expected, s.Snapshot[0])
+	})
+
+	t.Run("api defaults", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/liquidations/hist", r.RequestURI)
+			_, err := w.Write([]byte(`[]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		s, err := c.Liquidations.History(0, 0, 0, 0)
+		require.Nil(t, err)
+		assert.Empty(t, s.Snapshot)
+	})
+}
+
+func TestLiquidationsAllHistory(t *testing.T) {
+	// newest first, liquidations on page boundaries share mts
+	entries := []entry{
+		{10, 1000}, {9, 900}, {8, 900}, {7, 800}, {6, 700},
+		{5, 700}, {4, 600}, {3, 500}, {2, 400}, {1, 300},
+	}
+
+	requests := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "-1", r.URL.Query().Get("sort"))
+
+		res := []interface{}{}
+		for _, e := range history(entries, pageQuery(t, r)) {
+			res = append(res, rawLiquidation(e.id, e.mts))
+		}
+		payload, _ := json.Marshal(res)
+		_, err := w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	ls, err := c.Liquidations.AllHistory(rest.Page{Start: 400, Limit: 3})
+	require.Nil(t, err)
+
+	got := []entry{}
+	for _, l := range ls {
+		got = append(got, entry{l.PositionID, l.MTS})
+	}
+	assert.Equal(t, entries[:9], got)
+	assert.Equal(t, 6, requests)
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"path"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/margin"
+)
+
+// MarginService manages the Margin Info endpoint.
+type MarginService struct {
+	requestFactory
+	Synchronous
+}
+
+func (s *MarginService) info(key string) ([]interface{}, error) {
+	req, err := s.requestFactory.NewAuthenticatedRequest(common.PermissionRead, path.Join("info/margin", key))
+	if err != nil {
+		return nil, err
+	}
+	return s.Request(req)
+}
+
+// Base - retrieves account margin info
+// see https://docs.bitfinex.com/reference#rest-auth-info-margin for more info
+func (s *MarginService) Base() (*margin.InfoBase, error) {
+	raw, err := s.info("base")
+	if err != nil {
+		return nil, err
+	}
+	return margin.BaseFromRaw(raw)
+}
+
+// Symbol - retrieves margin info of the given trading pair
+// see https://docs.bitfinex.com/reference#rest-auth-info-margin for more info
+func (s *MarginService) Symbol(symbol string) (*margin.InfoUpdate, error) {
+	raw, err := s.info(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return margin.UpdateFromRaw(raw)
+}
+
+// AllSymbols - retrieves margin info of all trading pairs
+// see https://docs.bitfinex.com/reference#rest-auth-info-margin for more info
+func (s *MarginService) AllSymbols() (*margin.UpdateSnapshot, error) {
+	raw, err := s.info("sym_all")
+	if err != nil {
+		return nil, err
+	}
+	return margin.UpdateSnapshotFromRaw(raw)
+}
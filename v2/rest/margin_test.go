@@ -0,0 +1,88 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/margin"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marginServer responds to margin info requests with recorded payloads
+func marginServer(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/auth/r/info/margin/base":    `["base",[-13.014640000000007,0,49331.70267297,49318.68803297,27]]`,
+		"/auth/r/info/margin/tETHUSD": `["sym","tETHUSD",[149361.09689202,149639.26293509,830.0182168,895.0658432]]`,
+		"/auth/r/info/margin/sym_all": `[["sym","tETHUSD",[149361.09689202,149639.26293509,830.0182168,895.0658432]],["sym","tBTCUSD",[12.5,13.5,0.5,0.25]]]`,
+		"/auth/r/info/margin/tERRUSD": `["base",[-13.014640000000007,0,49331.70267297,49318.68803297,27]]`,
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		resp, ok := responses[r.RequestURI]
+		require.True(t, ok, r.RequestURI)
+		_, err := w.Write([]byte(resp))
+		require.Nil(t, err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(handler))
+}
+
+func TestMarginBase(t *testing.T) {
+	server := marginServer(t)
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Margin.Base()
+	require.Nil(t, err)
+
+	expected := &margin.InfoBase{
+		UserProfitLoss: -13.014640000000007,
+		UserSwaps:      0,
+		MarginBalance:  49331.70267297,
+		MarginNet:      49318.68803297,
+		MarginRequired: 27,
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestMarginSymbol(t *testing.T) {
+	server := marginServer(t)
+	defer server.Close()
+	c := rest.NewClientWithURL(server.URL)
+
+	t.Run("valid response", func(t *testing.T) {
+		got, err := c.Margin.Symbol("tETHUSD")
+		require.Nil(t, err)
+
+		expected := &margin.InfoUpdate{
+			Symbol:          "tETHUSD",
+			TradableBalance: 149361.09689202,
+			GrossBalance:    149639.26293509,
+			Buy:             830.0182168,
+			Sell:            895.0658432,
+		}
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("unexpected response", func(t *testing.T) {
+		got, err := c.Margin.Symbol("tERRUSD")
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func TestMarginAllSymbols(t *testing.T) {
+	server := marginServer(t)
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Margin.AllSymbols()
+	require.Nil(t, err)
+	require.Len(t, got.Snapshot, 2)
+	assert.Equal(t, "tETHUSD", got.Snapshot[0].Symbol)
+	assert.Equal(t, &margin.InfoUpdate{Symbol: "tBTCUSD", TradableBalance: 12.5, GrossBalance: 13.5, Buy: 0.5, Sell: 0.25}, got.Snapshot[1])
+}
@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/movement"
+)
+
+// MovementService manages deposits and withdrawals history endpoints
+type MovementService struct {
+	requestFactory
+	Synchronous
+}
+
+var maxMovementsLimit int32 = 1000
+
+// History - retrieves past deposits and withdrawals of the given currency,
+// or of all currencies when currency is empty. Zero start, end and limit
+// are left to api defaults
+// see https://docs.bitfinex.com/reference#rest-auth-movements for more info
+func (s *MovementService) History(currency string, start, end int64, limit int32) (*movement.Snapshot, error) {
+	if limit > maxMovementsLimit {
+		return nil, fmt.Errorf("Max request limit:%d, got: %d", maxMovementsLimit, limit)
+	}
+
+	payload := Page{Start: start, End: end, Limit: limit}.payload()
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, path.Join("movements", currency, "hist"), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return movement.SnapshotFromRaw(raw)
+}
+
+// Info - retrieves details of deposit or withdrawal with the given id
+// see https://docs.bitfinex.com/reference#movement-info for more info
+func (s *MovementService) Info(id int64) (*movement.Info, error) {
+	payload := map[string]interface{}{"id": id}
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, "movements/info", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return movement.InfoFromRaw(raw)
+}
+
+// number of consecutive failed lookups WatchWithdrawal gives up after
+const maxWatchFailures = 5
+
+// MovementUpdate is a status transition emitted by WatchWithdrawal
+type MovementUpdate struct {
+	Movement *movement.Info
+	// status before the transition, empty for the first update
+	Previous movement.Status
+	// failed lookup, polling carries on with backoff until too many of them
+	// fail in a row
+	Err error
+}
+
+// WatchWithdrawal polls withdrawal with the given id every interval and emits
+// its current status followed by every status transition. Interval doubles
+// with every consecutive failed lookup. Returned channel is closed once
+// withdrawal reaches final status, ctx is done, or after update with the
+// error of the 5th failed lookup in a row
+func (s *MovementService) WatchWithdrawal(ctx context.Context, id int64, interval time.Duration) <-chan MovementUpdate {
+	updates := make(chan MovementUpdate)
+
+	go func() {
+		defer close(updates)
+
+		var status movement.Status
+		failures := 0
+		for {
+			m, err := s.Info(id)
+			if err != nil {
+				failures++
+			} else {
+				failures = 0
+			}
+
+			switch {
+			case err != nil:
+				if !sendMovementUpdate(ctx, updates, MovementUpdate{Err: err}) {
+					return
+				}
+				if failures == maxWatchFailures {
+					return
+				}
+			case m.Status != status:
+				if !sendMovementUpdate(ctx, updates, MovementUpdate{Movement: m, Previous: status}) {
+					return
+				}
+				if m.Status.Final() {
+					return
+				}
+				status = m.Status
+			}
+
+			// back off while lookups keep failing
+			timer := time.NewTimer(interval << failures)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return updates
+}
+
+func sendMovementUpdate(ctx context.Context, updates chan<- MovementUpdate, u MovementUpdate) bool {
+	select {
+	case updates <- u:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,198 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/movement"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawMovement(id int64, status string) []interface{} {
+	return []interface{}{
+		id, "ETH", "ETHEREUM", nil, nil, 1569348774000, 1569348774000, nil, nil,
+		status, nil, nil, -0.26300954, -0.00135, nil, nil,
+		"0x5e2bd54e3e4e4f8c5b2e1f29e8a8ef4a3a45d3f4", nil, nil, nil,
+		"0x5a6f8e9d3b1e56b5b4d0f8d1c0b1b7a3b1b2c3d4", nil,
+	}
+}
+
+func TestMovementsHistory(t *testing.T) {
+	t.Run("limit too high", func(t *testing.T) {
+		c := rest.NewClient()
+		s, err := c.Movements.History("ETH", 0, 0, 1001)
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/r/movements/ETH/hist", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			gotReqPld := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"start": 1569348700000.0, "limit": 50.0}, gotReqPld)
+
+			respMock := []interface{}{rawMovement(13105603, "COMPLETED"), rawMovement(13105604, "PENDING")}
+			payload, _ := json.Marshal(respMock)
+			_, err = w.Write(payload)
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		s, err := c.Movements.History("ETH", 1569348700000, 0, 50)
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+		assert.Equal(t, movement.StatusCompleted, s.Snapshot[0].Status)
+		assert.Equal(t, int64(13105604), s.Snapshot[1].ID)
+	})
+
+	t.Run("all currencies", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/r/movements/hist", r.RequestURI)
+			_, err := w.Write([]byte(`[]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		s, err := c.Movements.History("", 0, 0, 0)
+		require.Nil(t, err)
+		assert.Empty(t, s.Snapshot)
+	})
+}
+
+func TestMovementInfo(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/movements/info", r.RequestURI)
+
+		gotReqPld := map[string]int64{}
+		err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]int64{"id": 13105603}, gotReqPld)
+
+		payload, _ := json.Marshal(rawMovement(13105603, "PENDING"))
+		_, err = w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	i, err := c.Movements.Info(13105603)
+	require.Nil(t, err)
+	assert.Equal(t, int64(13105603), i.ID)
+	assert.Equal(t, movement.StatusPending, i.Status)
+}
+
+func TestWatchWithdrawal(t *testing.T) {
+	// statuses returned by consecutive lookups, empty one fails the request
+	statuses := []string{"PENDING", "PENDING", "", "PROCESSING", "COMPLETED", "COMPLETED"}
+
+	var mtx sync.Mutex
+	calls := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		status := statuses[calls]
+		calls++
+		mtx.Unlock()
+
+		if status == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`["error",10020,"temporarily unavailable"]`))
+			return
+		}
+
+		payload, _ := json.Marshal(rawMovement(13105603, status))
+		_, _ = w.Write(payload)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	t.Run("emits transitions until final status", func(t *testing.T) {
+		c := rest.NewClientWithURL(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		var got []rest.MovementUpdate
+		for u := range c.Movements.WatchWithdrawal(ctx, 13105603, time.Millisecond) {
+			got = append(got, u)
+		}
+
+		require.Len(t, got, 4)
+		assert.Equal(t, movement.Status(""), got[0].Previous)
+		assert.Equal(t, movement.StatusPending, got[0].Movement.Status)
+		assert.NotNil(t, got[1].Err)
+		assert.Equal(t, movement.StatusPending, got[2].Previous)
+		assert.Equal(t, movement.Status("PROCESSING"), got[2].Movement.Status)
+		assert.Equal(t, movement.Status("PROCESSING"), got[3].Previous)
+		assert.Equal(t, movement.StatusCompleted, got[3].Movement.Status)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("stops when ctx is done", func(t *testing.T) {
+		mtx.Lock()
+		calls = 0
+		mtx.Unlock()
+
+		c := rest.NewClientWithURL(server.URL)
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := c.Movements.WatchWithdrawal(ctx, 13105603, time.Hour)
+
+		u := <-updates
+		assert.Equal(t, movement.StatusPending, u.Movement.Status)
+		cancel()
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+
+	t.Run("gives up after consecutive failures", func(t *testing.T) {
+		failing := 0
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failing++
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`["error",10020,"temporarily unavailable"]`))
+		}))
+		defer down.Close()
+
+		c := rest.NewClientWithURL(down.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		start := time.Now()
+		var got []rest.MovementUpdate
+		for u := range c.Movements.WatchWithdrawal(ctx, 13105603, time.Millisecond*2) {
+			got = append(got, u)
+		}
+
+		require.Len(t, got, 5)
+		for _, u := range got {
+			assert.NotNil(t, u.Err)
+			assert.Nil(t, u.Movement)
+		}
+		assert.Equal(t, 5, failing)
+		// waits between lookups double: 4, 8, 16 and 32ms
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(time.Millisecond*60))
+		assert.Nil(t, ctx.Err())
+	})
+}
@@ -17,6 +17,8 @@ type OrderService struct {
 	Synchronous
 }
 
+const maxOrderHistoryLimit = 2500
+
 type OrderIDs []int
 type GroupOrderIDs []int
 type ClientOrderIDs [][]interface{}
@@ -93,6 +95,99 @@ func (s *OrderService) GetHistoryByOrderId(orderID int64) (o *order.Order, err e
 	return nil, common.ErrNotFound
 }
 
+// Queries past orders with the given symbol, time window, limit and ids. Empty
+// symbol matches all symbols, zero values and empty ids are left out of query
+// See https://docs.bitfinex.com/reference#orders-history for more info
+func (s *OrderService) HistoryWithQuery(
+	symbol string,
+	start common.Mts,
+	end common.Mts,
+	limit common.QueryLimit,
+	ids []int64,
+) (*order.Snapshot, error) {
+	if limit > maxOrderHistoryLimit {
+		return nil, fmt.Errorf("max request limit is %d, got: %d", maxOrderHistoryLimit, limit)
+	}
+
+	payload := Page{Start: int64(start), End: int64(end), Limit: int32(limit)}.payload()
+	if len(ids) > 0 {
+		payload["id"] = ids
+	}
+
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, path.Join("orders", symbol, "hist"), payload)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		// past the oldest order
+		return &order.Snapshot{Snapshot: make([]*order.Order, 0)}, nil
+	}
+	return order.SnapshotFromRaw(raw)
+}
+
+// OrderHistoryIterator walks past orders page by page, from the most recently
+// updated ones to the oldest ones, see HistoryIterator
+type OrderHistoryIterator struct {
+	s      *OrderService
+	symbol string
+	ids    []int64
+	pages  *Paginator
+	orders []*order.Order
+	err    error
+}
+
+// Returns iterator over past orders with the given symbol and ids, starting
+// with the given page. Page limit is a size of every single request. Orders
+// returned again on the page boundary are dropped by order id
+func (s *OrderService) HistoryIterator(symbol string, first Page, ids ...int64) *OrderHistoryIterator {
+	return &OrderHistoryIterator{
+		s:      s,
+		symbol: symbol,
+		ids:    ids,
+		pages:  NewPaginator(first),
+	}
+}
+
+// Next fetches the next page and reports whether it has any orders. Iteration
+// ends once all pages are fetched or request fails, see Err
+func (it *OrderHistoryIterator) Next() bool {
+	for it.err == nil && it.pages.Next() {
+		p := it.pages.Page()
+		snap, err := it.s.HistoryWithQuery(it.symbol, common.Mts(p.Start), common.Mts(p.End), common.QueryLimit(p.Limit), it.ids)
+		if err != nil {
+			it.err = err
+			break
+		}
+
+		it.orders = make([]*order.Order, 0, len(snap.Snapshot))
+		for _, o := range snap.Snapshot {
+			if it.pages.Keep(o.ID, o.MTSUpdated) {
+				it.orders = append(it.orders, o)
+			}
+		}
+		if len(it.orders) > 0 {
+			return true
+		}
+	}
+
+	it.orders = nil
+	return false
+}
+
+// Orders returns orders of the current page not seen on previous pages
+func (it *OrderHistoryIterator) Orders() []*order.Order {
+	return it.orders
+}
+
+// Err returns error which ended iteration, if any
+func (it *OrderHistoryIterator) Err() error {
+	return it.err
+}
+
 // Retrieves the trades generated by an order
 // See https://docs.bitfinex.com/reference#orders-history for more info
 func (s *OrderService) OrderTrades(symbol string, orderID int64) (*tradeexecutionupdate.Snapshot, error) {
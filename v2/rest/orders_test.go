@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
@@ -375,3 +376,202 @@ func TestOrderMultiOp(t *testing.T) {
 		assert.Equal(t, int64(1568711312683), rsp.MTS)
 	})
 }
+
+func rawHistoryOrder(id, mts int64) []interface{} {
+	return []interface{}{
+		id, nil, 1337, "tBTCUSD", mts, mts, 0, 0.001, "EXCHANGE LIMIT", nil, nil, nil, "0",
+		"EXECUTED @ 8800.0(0.001)", nil, nil, 8800, 8800, 0, 0, nil, nil, nil, 0, 0, nil, nil, nil,
+		"API>BFX", nil, nil, nil,
+	}
+}
+
+func TestOrdersHistoryWithQuery(t *testing.T) {
+	t.Run("limit too high", func(t *testing.T) {
+		orders, err := NewClient().Orders.HistoryWithQuery("tBTCUSD", 0, 0, 2501, nil)
+		require.NotNil(t, err)
+		require.Nil(t, orders)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/r/orders/tBTCUSD/hist", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			gotReqPld := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			expected := map[string]interface{}{
+				"start": 1573482470000.0,
+				"end":   1573485380000.0,
+				"limit": 50.0,
+				"id":    []interface{}{33961681942.0, 33961681943.0},
+			}
+			assert.Equal(t, expected, gotReqPld)
+
+			payload, _ := json.Marshal([]interface{}{
+				rawHistoryOrder(33961681943, 1573485373000),
+				rawHistoryOrder(33961681942, 1573482478000),
+			})
+			_, err = w.Write(payload)
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		orders, err := NewClientWithURL(server.URL).Orders.HistoryWithQuery(
+			"tBTCUSD", 1573482470000, 1573485380000, 50, []int64{33961681942, 33961681943},
+		)
+		require.Nil(t, err)
+		require.Len(t, orders.Snapshot, 2)
+		assert.Equal(t, int64(33961681943), orders.Snapshot[0].ID)
+		assert.Equal(t, int64(1573482478000), orders.Snapshot[1].MTSUpdated)
+	})
+
+	t.Run("all symbols with default window", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/r/orders/hist", r.RequestURI)
+
+			gotReqPld := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			assert.Empty(t, gotReqPld)
+
+			_, err = w.Write([]byte(`[]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		orders, err := NewClientWithURL(server.URL).Orders.HistoryWithQuery("", 0, 0, 0, nil)
+		require.Nil(t, err)
+		assert.Empty(t, orders.Snapshot)
+	})
+}
+
+// historyServer serves orders between start and end, both inclusive, most
+// recently updated first and at most limit of them, like orders history does
+func historyServer(t *testing.T, orders [][2]int64, fail int) (*httptest.Server, *[]map[string]interface{}) {
+	var mtx sync.Mutex
+	requests := []map[string]interface{}{}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		pld := map[string]interface{}{}
+		err := json.NewDecoder(r.Body).Decode(&pld)
+		require.Nil(t, err)
+
+		mtx.Lock()
+		requests = append(requests, pld)
+		n := len(requests)
+		mtx.Unlock()
+
+		if n == fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`["error",10020,"temporarily unavailable"]`))
+			return
+		}
+
+		start, _ := pld["start"].(float64)
+		end, _ := pld["end"].(float64)
+		limit, _ := pld["limit"].(float64)
+
+		res := []interface{}{}
+		for _, o := range orders {
+			if (start != 0 && o[1] < int64(start)) || (end != 0 && o[1] > int64(end)) {
+				continue
+			}
+			if limit != 0 && len(res) == int(limit) {
+				break
+			}
+			res = append(res, rawHistoryOrder(o[0], o[1]))
+		}
+
+		payload, _ := json.Marshal(res)
+		_, _ = w.Write(payload)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(handler)), &requests
+}
+
+func TestOrdersHistoryIterator(t *testing.T) {
+	// id and mts of update, most recent first, orders on page boundaries
+	// share mts and come back on the next page
+	orders := [][2]int64{
+		{110, 1000}, {109, 900}, {108, 900}, {107, 900}, {106, 800},
+		{105, 700}, {104, 600}, {103, 600}, {102, 500}, {101, 400},
+	}
+
+	collect := func(it *OrderHistoryIterator) (ids []int64, pages int) {
+		for it.Next() {
+			pages++
+			for _, o := range it.Orders() {
+				ids = append(ids, o.ID)
+			}
+		}
+		return
+	}
+
+	t.Run("walks all pages without duplicates", func(t *testing.T) {
+		server, requests := historyServer(t, orders, 0)
+		defer server.Close()
+
+		it := NewClientWithURL(server.URL).Orders.HistoryIterator("tBTCUSD", Page{Limit: 3})
+		ids, pages := collect(it)
+		require.Nil(t, it.Err())
+		assert.Equal(t, []int64{110, 109, 108, 107, 106, 105, 104, 103, 102, 101}, ids)
+		assert.Equal(t, 5, pages)
+		assert.Len(t, *requests, 5)
+		assert.Equal(t, 900.0, (*requests)[1]["end"])
+	})
+
+	t.Run("skips page made of seen orders only", func(t *testing.T) {
+		// second page ends at mts of the first one and brings back the same orders
+		boundary := [][2]int64{{103, 900}, {102, 900}, {101, 800}}
+		server, requests := historyServer(t, boundary, 0)
+		defer server.Close()
+
+		it := NewClientWithURL(server.URL).Orders.HistoryIterator("", Page{Limit: 2})
+		ids, pages := collect(it)
+		require.Nil(t, it.Err())
+		assert.Equal(t, []int64{103, 102, 101}, ids)
+		assert.Equal(t, 2, pages)
+		require.Len(t, *requests, 3)
+		assert.Equal(t, 899.0, (*requests)[2]["end"])
+	})
+
+	t.Run("passes ids and stops at start", func(t *testing.T) {
+		server, requests := historyServer(t, orders, 0)
+		defer server.Close()
+
+		it := NewClientWithURL(server.URL).Orders.HistoryIterator("tBTCUSD", Page{Start: 650, Limit: 4}, 110, 105)
+		ids, _ := collect(it)
+		require.Nil(t, it.Err())
+		assert.Equal(t, []int64{110, 109, 108, 107, 106, 105}, ids)
+		assert.Equal(t, []interface{}{110.0, 105.0}, (*requests)[0]["id"])
+	})
+
+	t.Run("no orders", func(t *testing.T) {
+		server, requests := historyServer(t, nil, 0)
+		defer server.Close()
+
+		it := NewClientWithURL(server.URL).Orders.HistoryIterator("tBTCUSD", Page{Limit: 3})
+		assert.False(t, it.Next())
+		assert.Nil(t, it.Err())
+		assert.Nil(t, it.Orders())
+		assert.Len(t, *requests, 1)
+	})
+
+	t.Run("stops on request error", func(t *testing.T) {
+		server, requests := historyServer(t, orders, 2)
+		defer server.Close()
+
+		it := NewClientWithURL(server.URL).Orders.HistoryIterator("tBTCUSD", Page{Limit: 3})
+		ids, pages := collect(it)
+		require.NotNil(t, it.Err())
+		assert.Equal(t, []int64{110, 109, 108}, ids)
+		assert.Equal(t, 1, pages)
+		assert.False(t, it.Next())
+		assert.Len(t, *requests, 2)
+	})
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+)
+
+// Page is a time window and size of a history request. Zero values are left
+// to api defaults
+type Page struct {
+	Start int64
+	End   int64
+	Limit int32
+}
+
+func (p Page) payload() map[string]interface{} {
+	payload := map[string]interface{}{}
+	if p.Start != 0 {
+		payload["start"] = p.Start
+	}
+	if p.End != 0 {
+		payload["end"] = p.End
+	}
+	if p.Limit != 0 {
+		payload["limit"] = p.Limit
+	}
+	return payload
+}
+
+// params returns page as query params of public history endpoints, along
+// with the sort order. Zero values are left out
+func (p Page) params(sort common.SortOrder) url.Values {
+	params := make(url.Values)
+	if p.Start != 0 {
+		params.Add("start", strconv.FormatInt(p.Start, 10))
+	}
+	if p.End != 0 {
+		params.Add("end", strconv.FormatInt(p.End, 10))
+	}
+	if p.Limit != 0 {
+		params.Add("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	if sort != 0 {
+		params.Add("sort", strconv.FormatInt(int64(sort), 10))
+	}
+	return params
+}
+
+// Paginator walks history endpoints page by page, from the newest entries to
+// the oldest ones. Every next page ends at mts of the oldest entry of the
+// previous one, so entries sharing that mts come back again and are dropped
+// by Keep
+type Paginator struct {
+	first    Page
+	page     Page
+	started  bool
+	count    int
+	oldest   int64
+	seen     map[pageEntry]bool
+	maxLimit int32
+	widened  bool
+	err      error
+}
+
+type pageEntry struct {
+	id  int64
+	mts int64
+}
+
+// NewPaginator returns paginator starting with the given page
+func NewPaginator(first Page) *Paginator {
+	return &Paginator{
+		first: first,
+		page:  first,
+		seen:  make(map[pageEntry]bool),
+	}
+}
+
+// WithMaxLimit makes paginator fetch a page full of entries sharing single
+// mts again, only at that mts and with the given limit, instead of moving
+// past entries which did not fit. Iteration stops with Err if they do not
+// fit the given limit either. Meant for endpoints where no entry may be skipped
+func (p *Paginator) WithMaxLimit(limit int32) *Paginator {
+	p.maxLimit = limit
+	return p
+}
+
+// Err returns error iteration stopped with, if any
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+// Page returns window of the current page
+func (p *Paginator) Page() Page {
+	return p.page
+}
+
+// Next moves to the next page and reports whether it should be fetched.
+// Iteration ends with a page shorter than limit, once start is reached, or
+// with Err once entries sharing single mts do not fit max limit
+func (p *Paginator) Next() bool {
+	if !p.started {
+		p.started = true
+		return true
+	}
+
+	full := p.count > 0 && (p.page.Limit == 0 || p.count >= int(p.page.Limit))
+	end := p.oldest
+	switch {
+	case p.widened:
+		if full {
+			p.err = fmt.Errorf("more than %d entries share single mts %d", p.page.Limit, p.page.End)
+			return false
+		}
+		// whole mts is fetched, continue past it with limit of the first page
+		end = p.page.End - 1
+		p.page.Start, p.page.Limit = p.first.Start, p.first.Limit
+		p.widened = false
+	case !full:
+		return false
+	case p.page.End != 0 && end >= p.page.End:
+		// whole page shares single mts, there is no way to page within it
+		if p.maxLimit == 0 {
+			end = p.page.End - 1
+			break
+		}
+		if p.page.Limit != 0 && p.page.Limit >= p.maxLimit {
+			p.err = fmt.Errorf("more than %d entries share single mts %d", p.page.Limit, p.page.End)
+			return false
+		}
+		p.widened = true
+		p.page.Start, p.page.Limit = p.page.End, p.maxLimit
+		p.count = 0
+		return true
+	}
+
+	if p.page.Start != 0 && end < p.page.Start {
+		return false
+	}
+
+	for e := range p.seen {
+		if e.mts > end {
+			delete(p.seen, e)
+		}
+	}
+
+	p.page.End = end
+	p.count = 0
+	return true
+}
+
+// Keep registers entry of the current page and reports whether it was not
+// seen on previous pages
+func (p *Paginator) Keep(id, mts int64) bool {
+	p.count++
+	if p.count == 1 || mts < p.oldest {
+		p.oldest = mts
+	}
+
+	e := pageEntry{id: id, mts: mts}
+	if p.seen[e] {
+		return false
+	}
+	p.seen[e] = true
+	return true
+}
@@ -0,0 +1,121 @@
+package rest_test
+
+import (
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	id  int64
+	mts int64
+}
+
+// history mimics history endpoints: entries between start and end, both
+// inclusive, newest first, at most limit of them
+func history(entries []entry, p rest.Page) []entry {
+	res := []entry{}
+	for _, e := range entries {
+		if (p.Start != 0 && e.mts < p.Start) || (p.End != 0 && e.mts > p.End) {
+			continue
+		}
+		if p.Limit != 0 && len(res) == int(p.Limit) {
+			break
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+func paginate(entries []entry, first rest.Page) (got []entry, requests int) {
+	pages := rest.NewPaginator(first)
+	for pages.Next() {
+		requests++
+		for _, e := range history(entries, pages.Page()) {
+			if pages.Keep(e.id, e.mts) {
+				got = append(got, e)
+			}
+		}
+	}
+	return
+}
+
+func TestPaginator(t *testing.T) {
+	// newest first, some entries share mts
+	entries := []entry{
+		{10, 1000}, {9, 900}, {8, 900}, {7, 900}, {6, 800},
+		{5, 700}, {4, 600}, {3, 600}, {2, 500}, {1, 400},
+	}
+
+	t.Run("walks all pages without duplicates", func(t *testing.T) {
+		got, requests := paginate(entries, rest.Page{Limit: 3})
+		assert.Equal(t, entries, got)
+		assert.Equal(t, 5, requests)
+	})
+
+	t.Run("stops at start", func(t *testing.T) {
+		got, _ := paginate(entries, rest.Page{Start: 650, Limit: 4})
+		assert.Equal(t, entries[:6], got)
+	})
+
+	t.Run("starts at end", func(t *testing.T) {
+		got, _ := paginate(entries, rest.Page{End: 800, Limit: 4})
+		assert.Equal(t, entries[4:], got)
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		got, requests := paginate(entries, rest.Page{Limit: 20})
+		assert.Equal(t, entries, got)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("api default limit", func(t *testing.T) {
+		got, _ := paginate(entries, rest.Page{})
+		assert.Equal(t, entries, got)
+	})
+
+	t.Run("no entries", func(t *testing.T) {
+		got, requests := paginate(nil, rest.Page{Limit: 3})
+		assert.Empty(t, got)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("page full of single mts moves past it", func(t *testing.T) {
+		got, _ := paginate(entries, rest.Page{Limit: 2})
+		// entry 7 can not be reached, it does not fit a page at mts 900
+		expected := append(append([]entry{}, entries[:3]...), entries[4:]...)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("max limit fetches whole mts", func(t *testing.T) {
+		got, requests, err := paginateWith(entries, rest.NewPaginator(rest.Page{Limit: 2}).WithMaxLimit(5))
+		assert.Nil(t, err)
+		assert.Equal(t, entries, got)
+		assert.Equal(t, 9, requests)
+	})
+
+	t.Run("max limit fits page already", func(t *testing.T) {
+		got, _, err := paginateWith(entries, rest.NewPaginator(rest.Page{Limit: 3}).WithMaxLimit(5))
+		assert.Nil(t, err)
+		assert.Equal(t, entries, got)
+	})
+
+	t.Run("mts does not fit max limit", func(t *testing.T) {
+		got, _, err := paginateWith(entries, rest.NewPaginator(rest.Page{Limit: 1}).WithMaxLimit(2))
+		assert.NotNil(t, err)
+		assert.Equal(t, entries[:3], got)
+	})
+}
+
+func paginateWith(entries []entry, pages *rest.Paginator) (got []entry, requests int, err error) {
+	for pages.Next() {
+		requests++
+		for _, e := range history(entries, pages.Page()) {
+			if pages.Keep(e.id, e.mts) {
+				got = append(got, e)
+			}
+		}
+	}
+	return got, requests, pages.Err()
+}
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"errors"
+
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/notification"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/position"
@@ -53,3 +55,150 @@ func (s *PositionService) Claim(cp *position.ClaimRequest) (*notification.Notifi
 
 	return notification.FromRaw(raw)
 }
+
+// Increase - submits a request to increase an active position of the given
+// symbol. Notify info of returned notification is a *position.Position with
+// symbol, amount and base price set
+// see https://docs.bitfinex.com/reference#increase-position for more info
+func (s *PositionService) Increase(ir *position.IncreaseRequest) (*notification.Notification, error) {
+	if ir == nil || ir.Symbol == "" || ir.Amount == 0 {
+		return nil, errors.New("position symbol and amount are required")
+	}
+
+	bytes, err := ir.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionWrite, "position/increase", bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := notification.FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, ok := n.NotifyInfo.([]interface{}); ok {
+		n.NotifyInfo, err = position.IncreaseFromRaw(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// IncreaseInfo - retrieves how much position of the given symbol can be
+// increased by and funding the increase would require
+// see https://docs.bitfinex.com/reference#increase-position-info for more info
+func (s *PositionService) IncreaseInfo(ir *position.IncreaseRequest) (*position.IncreaseInfo, error) {
+	if ir == nil || ir.Symbol == "" {
+		return nil, errors.New("position symbol is required")
+	}
+
+	bytes, err := ir.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.requestFactory.NewAuthenticatedRequestWithBytes(common.PermissionRead, "position/increase/info", bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return position.IncreaseInfoFromRaw(raw)
+}
+
+func (s *PositionService) history(endpoint string, payload map[string]interface{}) (*position.Snapshot, error) {
+	req, err := s.requestFactory.NewAuthenticatedRequestWithData(common.PermissionRead, endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return position.HistorySnapshotFromRaw(raw)
+}
+
+// History - retrieves closed positions updated between start and end
+// see https://docs.bitfinex.com/reference#rest-auth-positions-history for more info
+func (s *PositionService) History(start, end int64, limit int32) (*position.Snapshot, error) {
+	return s.history("positions/hist", Page{Start: start, End: end, Limit: limit}.payload())
+}
+
+// Snapshot - retrieves positions as they were between start and end
+// see https://docs.bitfinex.com/reference#rest-auth-positions-snap for more info
+func (s *PositionService) Snapshot(start, end int64, limit int32) (*position.Snapshot, error) {
+	return s.history("positions/snap", Page{Start: start, End: end, Limit: limit}.payload())
+}
+
+// Audit - retrieves every update of positions with the given ids, including
+// collateral and meta details
+// see https://docs.bitfinex.com/reference#rest-auth-positions-audit for more info
+func (s *PositionService) Audit(ids []int64, start, end int64, limit int32) (*position.Snapshot, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("position ids are required")
+	}
+
+	payload := Page{Start: start, End: end, Limit: limit}.payload()
+	payload["id"] = ids
+	return s.history("positions/audit", payload)
+}
+
+// AllHistory - retrieves closed positions page by page, until start of the page
+// is reached. Page limit is a size of every single request
+func (s *PositionService) AllHistory(p Page) ([]*position.Position, error) {
+	return s.all(p, func(p Page) (*position.Snapshot, error) {
+		return s.History(p.Start, p.End, p.Limit)
+	})
+}
+
+// AllSnapshot - retrieves positions snapshot page by page, until start of the
+// page is reached. Page limit is a size of every single request
+func (s *PositionService) AllSnapshot(p Page) ([]*position.Position, error) {
+	return s.all(p, func(p Page) (*position.Snapshot, error) {
+		return s.Snapshot(p.Start, p.End, p.Limit)
+	})
+}
+
+// AllAudit - retrieves updates of positions with the given ids page by page,
+// until start of the page is reached. Page limit is a size of every single request
+func (s *PositionService) AllAudit(ids []int64, p Page) ([]*position.Position, error) {
+	return s.all(p, func(p Page) (*position.Snapshot, error) {
+		return s.Audit(ids, p.Start, p.End, p.Limit)
+	})
+}
+
+func (s *PositionService) all(p Page, fetch func(p Page) (*position.Snapshot, error)) ([]*position.Position, error) {
+	ps := make([]*position.Position, 0)
+	pages := NewPaginator(p)
+	for pages.Next() {
+		snap, err := fetch(pages.Page())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pos := range snap.Snapshot {
+			if pages.Keep(pos.Id, pos.MtsUpdate) {
+				ps = append(ps, pos)
+			}
+		}
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,199 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/position"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawPositionHistory(id, mts int64) []interface{} {
+	return []interface{}{
+		"tBTCUSD", "CLOSED", 0, 7290.1, -0.00021, 0, nil, nil, nil, nil, nil,
+		id, 1574002216000, mts,
+	}
+}
+
+func TestPositionsHistory(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/positions/hist", r.RequestURI)
+		assert.Equal(t, "POST", r.Method)
+
+		gotReqPld := map[string]int64{}
+		err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]int64{"start": 1574002000000, "end": 1574003000000, "limit": 50}, gotReqPld)
+
+		payload, _ := json.Marshal([]interface{}{rawPositionHistory(142355652, 1574002346000)})
+		_, err = w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Positions.History(1574002000000, 1574003000000, 50)
+	require.Nil(t, err)
+
+	expected := &position.Position{
+		Id:            142355652,
+		Symbol:        "tBTCUSD",
+		Status:        "CLOSED",
+		BasePrice:     7290.1,
+		MarginFunding: -0.00021,
+		MtsCreate:     1574002216000,
+		MtsUpdate:     1574002346000,
+	}
+	require.Len(t, got.Snapshot, 1)
+	assert.Equal(t, expected, got.Snapshot[0])
+}
+
+func TestPositionsSnapshot(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/positions/snap", r.RequestURI)
+		_, err := w.Write([]byte(`[]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Positions.Snapshot(0, 0, 0)
+	require.Nil(t, err)
+	assert.Empty(t, got.Snapshot)
+}
+
+func TestPositionsAudit(t *testing.T) {
+	t.Run("no ids", func(t *testing.T) {
+		c := rest.NewClient()
+		got, err := c.Positions.Audit(nil, 0, 0, 0)
+		require.NotNil(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/r/positions/audit", r.RequestURI)
+
+			gotReqPld := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"id": []interface{}{142355652.0}, "limit": 10.0}, gotReqPld)
+
+			_, err = w.Write([]byte(`[["tBTCUSD","ACTIVE",0.0195,8565.0267019,-0.00021,1,null,null,null,null,null,142355652,1574002216000,1574002216000,null,1,null,16.5,8.25,{"reason":"TRADE"}]]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		got, err := c.Positions.Audit([]int64{142355652}, 0, 0, 10)
+		require.Nil(t, err)
+		require.Len(t, got.Snapshot, 1)
+		assert.Equal(t, 16.5, got.Snapshot[0].Collateral)
+		assert.Equal(t, 8.25, got.Snapshot[0].CollateralMin)
+		assert.Equal(t, map[string]interface{}{"reason": "TRADE"}, got.Snapshot[0].Meta)
+	})
+}
+
+func TestPositionsAllHistory(t *testing.T) {
+	entries := []entry{{5, 1000}, {4, 900}, {3, 900}, {2, 800}, {1, 700}}
+	requests := 0
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/positions/hist", r.RequestURI)
+		requests++
+
+		p := rest.Page{}
+		err := json.NewDecoder(r.Body).Decode(&p)
+		require.Nil(t, err)
+
+		res := []interface{}{}
+		for _, e := range history(entries, p) {
+			res = append(res, rawPositionHistory(e.id, e.mts))
+		}
+		payload, _ := json.Marshal(res)
+		_, err = w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Positions.AllHistory(rest.Page{Start: 800, Limit: 2})
+	require.Nil(t, err)
+
+	ids := []int64{}
+	for _, p := range got {
+		ids = append(ids, p.Id)
+	}
+	assert.Equal(t, []int64{5, 4, 3, 2}, ids)
+	assert.Equal(t, 3, requests)
+}
+
+func TestPositionsIncrease(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		c := rest.NewClient()
+		n, err := c.Positions.Increase(&position.IncreaseRequest{Symbol: "tBTCUSD"})
+		require.NotNil(t, err)
+		require.Nil(t, n)
+	})
+
+	t.Run("calls correct resource with correct payload", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/auth/w/position/increase", r.RequestURI)
+			assert.Equal(t, "POST", r.Method)
+
+			gotReqPld := map[string]string{}
+			err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+			require.Nil(t, err)
+			assert.Equal(t, map[string]string{"symbol": "tBTCUSD", "amount": "0.0001"}, gotReqPld)
+
+			_, err = w.Write([]byte(`[1611767375264,"pos-inc-req",null,null,[["tBTCUSD",null,null,null,0.0001,34000.5]],null,"SUCCESS","Position increased"]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		n, err := c.Positions.Increase(&position.IncreaseRequest{Symbol: "tBTCUSD", Amount: 0.0001})
+		require.Nil(t, err)
+		assert.Equal(t, "SUCCESS", n.Status)
+		assert.Equal(t, &position.Position{Symbol: "tBTCUSD", Amount: 0.0001, BasePrice: 34000.5}, n.NotifyInfo)
+	})
+}
+
+func TestPositionsIncreaseInfo(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/r/position/increase/info", r.RequestURI)
+
+		gotReqPld := map[string]string{}
+		err := json.NewDecoder(r.Body).Decode(&gotReqPld)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]string{"symbol": "tBTCUSD", "amount": "0.5"}, gotReqPld)
+
+		_, err = w.Write([]byte(`[[1000,0,0.5,120.5,240.75,0.1,0.2],[350.25],null,null,[34.1,3.41],["USD","BTC"]]`))
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+	got, err := c.Positions.IncreaseInfo(&position.IncreaseRequest{Symbol: "tBTCUSD", Amount: 0.5})
+	require.Nil(t, err)
+	assert.Equal(t, 1000.0, got.MaxPos)
+	assert.Equal(t, 350.25, got.FundingAvail)
+	assert.Equal(t, 3.41, got.FundingRequired)
+	assert.Equal(t, "BTC", got.FundingRequiredCurrency)
+}
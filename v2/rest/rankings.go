@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ranking"
+)
+
+// maximum number of leaderboard entries api returns at once
+const maxRankingsLimit = 5000
+
+// RankingsService manages the Rankings (leaderboards) endpoint.
+type RankingsService struct {
+	requestFactory
+	Synchronous
+}
+
+// History - retrieves leaderboard of the given key, time frame and symbol,
+// e.g. tBTCUSD or tGLOBAL:USD, within the given time window. Zero values are
+// left to api defaults
+// see https://docs.bitfinex.com/reference#rest-public-rankings for more info
+func (s *RankingsService) History(
+	key ranking.Key,
+	tf ranking.TimeFrame,
+	symbol string,
+	start common.Mts,
+	end common.Mts,
+	limit common.QueryLimit,
+	sort common.SortOrder,
+) (*ranking.Snapshot, error) {
+	if key == "" || tf == "" || symbol == "" {
+		return nil, errors.New("ranking key, time frame and symbol are required")
+	}
+
+	req := NewRequestWithMethod(path.Join("rankings", fmt.Sprintf("%s:%s:%s", key, tf, symbol), "hist"), "GET")
+	req.Params = Page{Start: int64(start), End: int64(end), Limit: int32(limit)}.params(sort)
+	raw, err := s.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return ranking.SnapshotFromRaw(raw)
+}
+
+// AllHistory - retrieves leaderboard of the given key, time frame and symbol
+// page by page, newest first, until start of the page is reached. Page limit
+// is a size of every single request. All places of a leaderboard share mts,
+// leaderboard which does not fit a page is fetched again at once, with the
+// maximum limit
+func (s *RankingsService) AllHistory(key ranking.Key, tf ranking.TimeFrame, symbol string, p Page) ([]*ranking.Ranking, error) {
+	rs := make([]*ranking.Ranking, 0)
+	pages := NewPaginator(p).WithMaxLimit(maxRankingsLimit)
+	for pages.Next() {
+		pg := pages.Page()
+		snap, err := s.History(key, tf, symbol, common.Mts(pg.Start), common.Mts(pg.End), common.QueryLimit(pg.Limit), common.NewestFirst)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range snap.Snapshot {
+			// entries have no id, place on the leaderboard is unique at the given mts
+			if pages.Keep(r.Ranking, r.MTS) {
+				rs = append(rs, r)
+			}
+		}
+	}
+
+	if err := pages.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
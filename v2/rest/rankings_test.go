@@ -0,0 +1,115 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/ranking"
+	"github.com/bitfinexcom/bitfinex-api-go/v2/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawRanking(place, mts int64) []interface{} {
+	return []interface{}{mts, nil, "trader", place, nil, nil, 1000.5 / float64(place), nil, nil, nil}
+}
+
+func TestRankingsHistory(t *testing.T) {
+	t.Run("missing arguments", func(t *testing.T) {
+		c := rest.NewClient()
+		s, err := c.Rankings.History(ranking.KeyVolume, ranking.ThreeHours, "", 0, 0, 0, 0)
+		require.NotNil(t, err)
+		require.Nil(t, s)
+
+		s, err = c.Rankings.History("", ranking.ThreeHours, "tBTCUSD", 0, 0, 0, 0)
+		require.NotNil(t, err)
+		require.Nil(t, s)
+	})
+
+	t.Run("calls correct resource with correct params", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/rankings/plu_diff:1w:tGLOBAL:USD/hist?limit=2&sort=-1", r.RequestURI)
+			assert.Equal(t, "GET", r.Method)
+
+			_, err := w.Write([]byte(`[[1609144352338,null,"first",1,null,null,2001.5,null,null,"first_twitter"],[1609144352338,null,"second",2,null,null,1000.5,null,null,null]]`))
+			require.Nil(t, err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := rest.NewClientWithURL(server.URL)
+		s, err := c.Rankings.History(ranking.KeyUnrealizedProfitDiff, ranking.OneWeek, "tGLOBAL:USD", 0, 0, 2, common.NewestFirst)
+		require.Nil(t, err)
+		require.Len(t, s.Snapshot, 2)
+
+		expected := &ranking.Ranking{
+			MTS:           1609144352338,
+			Username:      "first",
+			Ranking:       1,
+			Value:         2001.5,
+			TwitterHandle: "first_twitter",
+		}
+		assert.Equal(t, expected, s.Snapshot[0])
+		assert.Equal(t, int64(2), s.Snapshot[1].Ranking)
+	})
+}
+
+func TestRankingsAllHistory(t *testing.T) {
+	// leaderboard snapshots, newest first, every one made of several places
+	// sharing mts; entry id stands for place on the leaderboard
+	entries := []entry{
+		{1, 3000}, {2, 3000}, {3, 3000},
+		{1, 2000}, {2, 2000}, {3, 2000},
+		{1, 1000}, {2, 1000},
+	}
+
+	requests := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/rankings/vol:3h:tBTCUSD/hist", r.URL.Path)
+
+		res := []interface{}{}
+		for _, e := range history(entries, pageQuery(t, r)) {
+			res = append(res, rawRanking(e.id, e.mts))
+		}
+		payload, _ := json.Marshal(res)
+		_, err := w.Write(payload)
+		require.Nil(t, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	c := rest.NewClientWithURL(server.URL)
+
+	t.Run("walks all snapshots", func(t *testing.T) {
+		requests = 0
+		rs, err := c.Rankings.AllHistory(ranking.KeyVolume, ranking.ThreeHours, "tBTCUSD", rest.Page{Limit: 4})
+		require.Nil(t, err)
+
+		got := []entry{}
+		for _, r := range rs {
+			got = append(got, entry{r.Ranking, r.MTS})
+		}
+		assert.Equal(t, entries, got)
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("snapshot larger than limit", func(t *testing.T) {
+		// places past the limit share mts, they are fetched again at once
+		requests = 0
+		rs, err := c.Rankings.AllHistory(ranking.KeyVolume, ranking.ThreeHours, "tBTCUSD", rest.Page{Limit: 2})
+		require.Nil(t, err)
+
+		got := []entry{}
+		for _, r := range rs {
+			got = append(got, entry{r.Ranking, r.MTS})
+		}
+		assert.Equal(t, entries, got)
+		assert.Equal(t, 10, requests)
+	})
+}
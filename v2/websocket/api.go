@@ -3,6 +3,7 @@ package websocket
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/book"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
@@ -10,6 +11,7 @@ import (
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingloan"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/fundingoffer"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/order"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/ratelimit"
 )
 
 type FlagRequest struct {
@@ -51,6 +53,46 @@ func (c *Client) EnableFlag(ctx context.Context, flag int) (string, error) {
 	return "", nil
 }
 
+// sendAuthenticated sends msg through the authenticated socket and tracks it
+// until acknowledged. If order rate limiting is enabled, msg gets queued
+// according to its priority first
+func (c *Client) sendAuthenticated(ctx context.Context, msg interface{}) error {
+	key := c.acks.Add(msg)
+	err := c.send(ctx, msg)
+	if err != nil {
+		c.acks.Remove(key)
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, msg interface{}) error {
+	socket, err := c.GetAuthenticatedSocket()
+	if err != nil {
+		return err
+	}
+	if c.orderGovernor == nil {
+		return socket.Asynchronous.Send(ctx, msg)
+	}
+
+	return c.orderGovernor.Submit(ctx, ratelimit.OrderOp(msg, func(msg interface{}) error {
+		// socket might have been replaced while queued
+		socket, err := c.GetAuthenticatedSocket()
+		if err != nil {
+			return err
+		}
+		return socket.Asynchronous.Send(ctx, msg)
+	}))
+}
+
+// OrderQueueStats returns depth and wait times of the authenticated order
+// operations queue. Zero value is returned if OrderRateLimit is disabled
+func (c *Client) OrderQueueStats() ratelimit.Stats {
+	if c.orderGovernor == nil {
+		return ratelimit.Stats{}
+	}
+	return c.orderGovernor.Stats()
+}
+
 // Gen the count of currently active websocket connections
 func (c *Client) ConnectionCount() int {
 	c.mtx.RLock()
@@ -80,8 +122,19 @@ func (c *Client) subscribeBySocket(ctx context.Context, socket *Socket, req *Sub
 	return req.SubID, nil
 }
 
-// Submit a request to subscribe to the given SubscriptionRequuest
+// Submit a request to subscribe to the given SubscriptionRequuest. If SubscribeRateLimit
+// is set, the call blocks until the request leaves the subscribe queue
 func (c *Client) Subscribe(ctx context.Context, req *SubscriptionRequest) (string, error) {
+	var subID string
+	err := c.throttleSubscribe(ctx, func() error {
+		var err error
+		subID, err = c.subscribe(ctx, req)
+		return err
+	})
+	return subID, err
+}
+
+func (c *Client) subscribe(ctx context.Context, req *SubscriptionRequest) (string, error) {
 	if c.getTotalAvailableSocketCapacity() <= 1 {
 		err := c.StartNewConnection()
 		if err != nil {
@@ -96,6 +149,74 @@ func (c *Client) Subscribe(ctx context.Context, req *SubscriptionRequest) (strin
 	return c.subscribeBySocket(ctx, socket, req)
 }
 
+// throttleSubscribe runs send right away or, if SubscribeRateLimit is set,
+// once the subscribe rate limit allows it
+func (c *Client) throttleSubscribe(ctx context.Context, send func() error) error {
+	if c.subscribeGovernor == nil {
+		return send()
+	}
+	return c.subscribeGovernor.Submit(ctx, ratelimit.Op{
+		Priority: ratelimit.PriorityNormal,
+		Send:     send,
+	})
+}
+
+// retrySubscription moves subscription rejected due to the channel limit of
+// given socket to another socket, opening a new connection if all are full
+func (c *Client) retrySubscription(socketId SocketId, subID string) error {
+	sub, err := c.subscriptions.lookupBySubscriptionID(subID)
+	if err != nil {
+		return err
+	}
+	if err := c.subscriptions.removeBySubscriptionID(subID); err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	c.subscribeRetries[subID]++
+	attempt := c.subscribeRetries[subID]
+	if attempt > c.parameters.SubscribeRetryAttempts {
+		delete(c.subscribeRetries, subID)
+		c.mtx.Unlock()
+		return fmt.Errorf("subscription %s rejected by channel limit after %d retries", sub.Request.String(), attempt-1)
+	}
+	c.mtx.Unlock()
+
+	c.log.Warningf("socket (id=%d) channel limit reached, retrying %s (%d/%d)", socketId, sub.Request.String(), attempt, c.parameters.SubscribeRetryAttempts)
+	go func() {
+		wait := time.NewTimer(c.parameters.SubscribeRetryInterval)
+		defer wait.Stop()
+		select {
+		case <-wait.C:
+		case <-c.shutdown:
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.parameters.SubscribeRetryTimeout)
+		defer cancel()
+		go func() {
+			// do not subscribe on a client being closed
+			select {
+			case <-c.shutdown:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := c.throttleSubscribe(ctx, func() error {
+			socket, err := c.getAvailableSocketExcept(socketId)
+			if err != nil {
+				return err
+			}
+			_, err = c.subscribeBySocket(ctx, socket, sub.Request)
+			return err
+		})
+		if err != nil {
+			c.log.Errorf("could not retry subscription %s: %s", sub.Request.String(), err.Error())
+		}
+	}()
+	return nil
+}
+
 // Submit a request to receive ticker updates
 func (c *Client) SubscribeTicker(ctx context.Context, symbol string) (string, error) {
 	req := &SubscriptionRequest{
@@ -175,29 +296,17 @@ func (c *Client) GetOrderbook(symbol string) (*Orderbook, error) {
 
 // Submit a request to create a new order
 func (c *Client) SubmitOrder(ctx context.Context, onr *order.NewRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, onr)
+	return c.sendAuthenticated(ctx, onr)
 }
 
 // Submit and update request to change an existing orders values
 func (c *Client) SubmitUpdateOrder(ctx context.Context, our *order.UpdateRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, our)
+	return c.sendAuthenticated(ctx, our)
 }
 
 // Submit a cancel request for an existing order
 func (c *Client) SubmitCancel(ctx context.Context, ocr *order.CancelRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, ocr)
+	return c.sendAuthenticated(ctx, ocr)
 }
 
 // Get a subscription request using a subscription ID
@@ -211,36 +320,20 @@ func (c *Client) LookupSubscription(subID string) (*SubscriptionRequest, error)
 
 // Submit a new funding offer request
 func (c *Client) SubmitFundingOffer(ctx context.Context, fundingOffer *fundingoffer.SubmitRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, fundingOffer)
+	return c.sendAuthenticated(ctx, fundingOffer)
 }
 
 // Submit a request to cancel and existing funding offer
 func (c *Client) SubmitFundingCancel(ctx context.Context, fundingOffer *fundingoffer.CancelRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, fundingOffer)
+	return c.sendAuthenticated(ctx, fundingOffer)
 }
 
 // CloseFundingLoan - cancels funding loan by ID. Emits an error if not authenticated.
 func (c *Client) CloseFundingLoan(ctx context.Context, flcr *fundingloan.CancelRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, flcr)
+	return c.sendAuthenticated(ctx, flcr)
 }
 
 // CloseFundingCredit - cancels funding credit by ID. Emits an error if not authenticated.
 func (c *Client) CloseFundingCredit(ctx context.Context, fundingOffer *fundingcredit.CancelRequest) error {
-	socket, err := c.GetAuthenticatedSocket()
-	if err != nil {
-		return err
-	}
-	return socket.Asynchronous.Send(ctx, fundingOffer)
+	return c.sendAuthenticated(ctx, fundingOffer)
 }
@@ -30,50 +30,47 @@ func (c *Client) handleChannel(socketId SocketId, msg []byte) error {
 		return fmt.Errorf("received a message after close")
 	}
 
-	var raw []interface{}
-	err := json.Unmarshal(msg, &raw)
+	f, err := parseFrame(msg)
 	if err != nil {
 		return err
-	} else if len(raw) < 2 {
+	} else if f.len < 2 {
 		return nil
 	}
 
-	chID, ok := raw[0].(float64)
-	if !ok {
-		return fmt.Errorf("expected message to start with a channel id but got %#v instead", raw[0])
-	}
-
-	chanID := int64(chID)
-	sub, err := c.subscriptions.lookupBySocketChannelID(chanID, socketId)
+	sub, err := c.subscriptions.lookupBySocketChannelID(f.chanID, socketId)
 	if err != nil {
 		// no subscribed channel for message
 		return err
 	}
-	c.subscriptions.heartbeat(chanID)
-	if sub.Public {
-		switch data := raw[1].(type) {
-		case string:
-			switch data {
-			case "hb":
-				// no-op, already updated heartbeat timeout from this event
-				return nil
-			case "cs":
-				if checksum, ok := raw[2].(float64); ok {
-					return c.handleChecksumChannel(sub, int(checksum))
-				} else {
-					c.log.Error("Unable to parse checksum")
-				}
-			default:
-				body := raw[2].([]interface{})
-				return c.handlePublicChannel(sub, sub.Request.Channel, data, body, msg)
-			}
-		case []interface{}:
-			return c.handlePublicChannel(sub, sub.Request.Channel, "", data, msg)
+	c.subscriptions.heartbeat(f.chanID)
+	if !sub.Public {
+		return c.handlePrivateChannel(f)
+	}
+
+	switch f.typ {
+	case "hb":
+		// no-op, already updated heartbeat timeout from this event
+		return nil
+	case "cs":
+		if f.pld == nil {
+			return fmt.Errorf("expected checksum in third position but got %s", msg)
 		}
-	} else {
-		return c.handlePrivateChannel(raw)
+		if convert.IsNumber(f.pld) {
+			return c.handleChecksumChannel(sub, int(convert.I64BytesOrZero(f.pld)))
+		}
+		c.log.Error("Unable to parse checksum")
+		return nil
+	case "":
+		if convert.IsArray(f.pld) {
+			return c.handlePublicChannel(sub, sub.Request.Channel, "", f.pld, msg)
+		}
+		return nil
 	}
-	return nil
+
+	if !convert.IsArray(f.pld) {
+		return fmt.Errorf("expected data list in third position but got %s", msg)
+	}
+	return c.handlePublicChannel(sub, sub.Request.Channel, f.typ, f.pld, msg)
 }
 
 func (c *Client) handleChecksumChannel(sub *subscription, checksum int) error {
@@ -114,67 +111,92 @@ func (c *Client) handleChecksumChannel(sub *subscription, checksum int) error {
 	return nil
 }
 
-func (c *Client) handlePublicChannel(sub *subscription, channel, objType string, data []interface{}, raw_msg []byte) error {
+func (c *Client) handlePublicChannel(sub *subscription, channel, objType string, pld []byte, raw_msg []byte) error {
 	// unauthenticated data slice
 	// public data is returned as raw interface arrays, use a factory to convert to raw type & publish
-	if factory, ok := c.factories[channel]; ok {
-		// convert to type array of interfaces
-		if len(data) > 0 {
-			if _, ok := data[0].([]interface{}); ok {
-				interfaceArray := convert.ToInterfaceArray(data)
-				// snapshot item
-				c.mtx.Lock()
-				// lock mutex since its mutates client struct
-				msg, err := factory.BuildSnapshot(sub, interfaceArray, raw_msg)
-				c.mtx.Unlock()
-				if err != nil {
-					return err
-				}
-				if msg != nil {
-					c.listener <- msg
-				}
-			} else {
-				// single item
-				msg, err := factory.Build(sub, objType, data, raw_msg)
-				if err != nil {
-					return err
-				}
-				if msg != nil {
-					c.listener <- msg
-				}
-			}
-		}
-	} else {
+	factory, ok := c.factories[channel]
+	if !ok {
 		// factory lookup error
 		return fmt.Errorf("could not find public factory for %s channel", channel)
 	}
+
+	empty, snapshot := payloadShape(pld)
+	if empty {
+		return nil
+	}
+
+	var msg interface{}
+	var err error
+	if snapshot {
+		// lock mutex since its mutates client struct
+		c.mtx.Lock()
+		msg, err = c.buildSnapshot(factory, sub, pld, raw_msg)
+		c.mtx.Unlock()
+	} else {
+		msg, err = c.build(factory, sub, objType, pld, raw_msg)
+	}
+	if err != nil {
+		return err
+	}
+	if msg != nil {
+		c.publish(msg)
+	}
 	return nil
 }
 
-func (c *Client) handlePrivateChannel(raw []interface{}) error {
+// build creates single item message, straight from payload if factory supports it
+func (c *Client) build(factory messageFactory, sub *subscription, objType string, pld []byte, raw_msg []byte) (interface{}, error) {
+	if ff, ok := factory.(frameFactory); ok {
+		return ff.BuildFrame(sub, objType, pld)
+	}
+
+	var data []interface{}
+	if err := json.Unmarshal(pld, &data); err != nil {
+		return nil, err
+	}
+	return factory.Build(sub, objType, data, raw_msg)
+}
+
+// buildSnapshot creates snapshot message, straight from payload if factory supports it
+func (c *Client) buildSnapshot(factory messageFactory, sub *subscription, pld []byte, raw_msg []byte) (interface{}, error) {
+	if ff, ok := factory.(frameFactory); ok {
+		return ff.BuildFrameSnapshot(sub, pld)
+	}
+
+	var data []interface{}
+	if err := json.Unmarshal(pld, &data); err != nil {
+		return nil, err
+	}
+	return factory.BuildSnapshot(sub, convert.ToInterfaceArray(data), raw_msg)
+}
+
+func (c *Client) handlePrivateChannel(f frame) error {
 	// authenticated data slice, or a heartbeat
-	if val, ok := raw[1].(string); ok && val == "hb" {
-		chanID, ok := raw[0].(float64)
-		if !ok {
-			c.log.Warningf("could not find chanID: %#v", raw)
-			return nil
-		}
-		c.handleHeartbeat(int64(chanID))
-	} else {
-		// raw[2] is data slice
-		// authenticated snapshots?
-		if len(raw) > 2 {
-			if arr, ok := raw[2].([]interface{}); ok {
-				obj, err := c.handlePrivateDataMessage(raw[1].(string), arr)
-				if err != nil {
-					return err
-				}
-				// private data is returned as strongly typed data, publish directly
-				if obj != nil {
-					c.listener <- obj
-				}
-			}
-		}
+	if f.typ == "hb" {
+		c.handleHeartbeat(f.chanID)
+		return nil
+	}
+
+	// authenticated snapshots?
+	if f.typ == "" || !convert.IsArray(f.pld) {
+		return nil
+	}
+
+	var arr []interface{}
+	if err := json.Unmarshal(f.pld, &arr); err != nil {
+		return err
+	}
+
+	obj, err := c.handlePrivateDataMessage(f.typ, arr)
+	if err != nil {
+		return err
+	}
+	if n, ok := obj.(*notification.Notification); ok {
+		c.acks.Ack(n)
+	}
+	// private data is returned as strongly typed data, publish directly
+	if obj != nil {
+		c.publish(obj)
 	}
 	return nil
 }
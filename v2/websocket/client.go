@@ -13,6 +13,8 @@ import (
 	"github.com/op/go-logging"
 
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/models/common"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/inflight"
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/ratelimit"
 	"github.com/bitfinexcom/bitfinex-api-go/pkg/utils"
 
 	"crypto/hmac"
@@ -110,6 +112,7 @@ type Client struct {
 	apiKey             string
 	apiSecret          string
 	cancelOnDisconnect bool
+	authFilter         []string
 	Authentication     AuthState
 	sockets            map[SocketId]*Socket
 	nonce              utils.NonceGenerator
@@ -125,8 +128,20 @@ type Client struct {
 	factories     map[string]messageFactory
 	orderbooks    map[string]*Orderbook
 
+	// authenticated order operations rate limiter, nil if disabled
+	orderGovernor *ratelimit.Governor
+	// subscribe requests rate limiter, nil if disabled
+	subscribeGovernor *ratelimit.Governor
+	// retry attempts of subscriptions rejected by channel limit, by subID
+	subscribeRetries map[string]int
+	// authenticated requests awaiting acknowledgement
+	acks *inflight.Tracker
+
 	// close signal sent to user on shutdown
-	shutdown chan bool
+	shutdown  chan bool
+	closeOnce sync.Once
+	// socket failures reconnect gave up on, Run returns on them
+	failed chan error
 
 	// downstream listener channel to deliver API objects
 	listener chan interface{}
@@ -149,6 +164,15 @@ func (c *Client) CancelOnDisconnect(cxl bool) *Client {
 	return c
 }
 
+// AuthFilter scopes the authenticated channel to the given filters, i.e. only
+// messages matching at least one filter are delivered. Filters are preserved
+// and re-applied when the authenticated socket reconnects. Use filter.New() to
+// build the list.
+func (c *Client) AuthFilter(filters []string) *Client {
+	c.authFilter = filters
+	return c
+}
+
 func (c *Client) sign(msg string) (string, error) {
 	sig := hmac.New(sha512.New384, []byte(c.apiSecret))
 	_, err := sig.Write([]byte(msg))
@@ -195,19 +219,28 @@ func NewWithParamsAsyncFactory(params *Parameters, async AsynchronousFactory) *C
 // NewWithParamsAsyncFactoryNonce creates a new client with a given set of parameters, asynchronous transport factory, and nonce generator interfaces.
 func NewWithParamsAsyncFactoryNonce(params *Parameters, async AsynchronousFactory, nonce utils.NonceGenerator) *Client {
 	c := &Client{
-		asyncFactory:   async,
-		Authentication: NoAuthentication,
-		factories:      make(map[string]messageFactory),
-		subscriptions:  newSubscriptions(params.HeartbeatTimeout, params.Logger),
-		orderbooks:     make(map[string]*Orderbook),
-		nonce:          nonce,
-		parameters:     params,
-		listener:       make(chan interface{}),
-		terminal:       false,
-		shutdown:       nil,
-		sockets:        make(map[SocketId]*Socket),
-		mtx:            &sync.RWMutex{},
-		log:            params.Logger,
+		asyncFactory:     async,
+		Authentication:   NoAuthentication,
+		factories:        make(map[string]messageFactory),
+		subscriptions:    newSubscriptions(params.HeartbeatTimeout, params.Logger),
+		orderbooks:       make(map[string]*Orderbook),
+		subscribeRetries: make(map[string]int),
+		acks:             inflight.New(),
+		nonce:            nonce,
+		parameters:       params,
+		listener:         make(chan interface{}),
+		terminal:         false,
+		shutdown:         make(chan bool),
+		failed:           make(chan error, 1),
+		sockets:          make(map[SocketId]*Socket),
+		mtx:              &sync.RWMutex{},
+		log:              params.Logger,
+	}
+	if params.OrderRateLimit > 0 {
+		c.orderGovernor = ratelimit.New(params.OrderRateLimit, params.OrderRateInterval)
+	}
+	if params.SubscribeRateLimit > 0 {
+		c.subscribeGovernor = ratelimit.New(params.SubscribeRateLimit, params.SubscribeRateInterval)
 	}
 	c.registerPublicFactories()
 	return c
@@ -216,11 +249,45 @@ func NewWithParamsAsyncFactoryNonce(params *Parameters, async AsynchronousFactor
 // Connect to the Bitfinex API, this should only be called once.
 func (c *Client) Connect() error {
 	c.dumpParams()
+	c.mtx.Lock()
 	c.terminal = false
+	c.mtx.Unlock()
 	go c.listenDisconnect()
 	return c.connectSocket(SocketId(len(c.sockets)))
 }
 
+// Run connects to the Bitfinex API and blocks until ctx is done. On cancellation
+// it waits up to ShutdownTimeout for acknowledgements of in-flight order requests
+// before closing the client. Run also returns once the client is closed, or
+// with an error once a socket could not be reconnected. Consumers should keep
+// reading Listen() until it gets closed.
+func (c *Client) Run(ctx context.Context) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-c.shutdown:
+		return nil
+	case err := <-c.failed:
+		c.Close()
+		return err
+	}
+
+	if !c.acks.Wait(c.parameters.ShutdownTimeout) {
+		c.log.Warningf("closing with %d unacknowledged requests in flight", c.acks.Pending())
+	}
+	c.Close()
+	return nil
+}
+
+// PendingAcks returns number of order requests sent but not yet acknowledged
+// with a notification
+func (c *Client) PendingAcks() int {
+	return c.acks.Pending()
+}
+
 // Returns true if the underlying asynchronous transport is connected to an endpoint.
 func (c *Client) IsConnected() bool {
 	c.mtx.RLock()
@@ -239,27 +306,54 @@ func (c *Client) Listen() <-chan interface{} {
 	return c.listener
 }
 
+// publish delivers obj to the listener, unless client is shut down in the
+// meantime, so a consumer which stopped reading does not block Close
+func (c *Client) publish(obj interface{}) {
+	select {
+	case c.listener <- obj:
+	case <-c.shutdown:
+	}
+}
+
 // Close the websocket client which will cause for all
 // active sockets to be exited and the Done() function
-// to be called
+// to be called. Subsequent calls are no-op
 func (c *Client) Close() {
+	c.closeOnce.Do(c.close)
+}
+
+func (c *Client) close() {
+	// sockets connected once terminal is set close themselves, see connectSocket
+	c.mtx.Lock()
 	c.terminal = true
-	var wg sync.WaitGroup
-	socketCount := len(c.sockets)
-	if socketCount > 0 {
-		for _, socket := range c.sockets {
-			if socket.IsConnected {
-				wg.Add(1)
-				socket.IsConnected = false
-				go func(s *Socket) {
-					c.closeAsyncAndWait(s, c.parameters.ShutdownTimeout)
-					wg.Done()
-				}(socket)
-			}
+	connected := make([]*Socket, 0, len(c.sockets))
+	for _, socket := range c.sockets {
+		if socket.IsConnected {
+			socket.IsConnected = false
+			connected = append(connected, socket)
 		}
-		wg.Wait()
+	}
+	c.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	for _, socket := range connected {
+		wg.Add(1)
+		go func(s *Socket) {
+			c.closeAsyncAndWait(s, c.parameters.ShutdownTimeout)
+			wg.Done()
+		}(socket)
+	}
+	wg.Wait()
+	if c.orderGovernor != nil {
+		c.orderGovernor.Close()
+	}
+	if c.subscribeGovernor != nil {
+		c.subscribeGovernor.Close()
 	}
 	c.subscriptions.Close()
+	close(c.shutdown)
+	// make sure nothing gets published after listener is closed
+	c.waitGroup.Wait()
 	close(c.listener)
 }
 
@@ -289,9 +383,9 @@ func (c *Client) listenDisconnect() {
 					go func() {
 						c.closeAsyncAndWait(socket, c.parameters.ShutdownTimeout)
 						err := c.reconnect(socket, hbErr.Error)
-						if err != nil {
+						if err != nil && !c.isTerminal() {
 							c.log.Warningf("socket disconnect: %s", err.Error())
-							return
+							c.fail(fmt.Errorf("socket (id=%d) lost: %s", socket.Id, err))
 						}
 					}()
 				}
@@ -325,24 +419,21 @@ func (c *Client) registerPublicFactories() {
 }
 
 func (c *Client) reconnect(socket *Socket, err error) error {
-	c.mtx.RLock()
-	if c.terminal {
-		// dont attempt to reconnect if terminal
-		c.mtx.RUnlock()
-		return err
-	}
 	if !c.parameters.AutoReconnect {
-		err := fmt.Errorf("AutoReconnect setting is disabled, do not reconnect: %s", err.Error())
-		c.mtx.RUnlock()
-		return err
+		return fmt.Errorf("AutoReconnect setting is disabled, do not reconnect: %s", err.Error())
 	}
-	c.mtx.RUnlock()
 	reconnectTry := 0
 	for ; reconnectTry < c.parameters.ReconnectAttempts; reconnectTry++ {
+		// dont attempt to reconnect if terminal
+		if c.isTerminal() {
+			return err
+		}
 		c.log.Debugf("socket (id=%d) waiting %s until reconnect...", socket.Id, c.parameters.ReconnectInterval)
-		time.Sleep(c.parameters.ReconnectInterval)
+		if !c.sleep(c.parameters.ReconnectInterval) || c.isTerminal() {
+			return err
+		}
 		c.log.Infof("socket (id=%d) reconnect attempt %d/%d", socket.Id, reconnectTry+1, c.parameters.ReconnectAttempts)
-		if err := c.reconnectSocket(socket); err == nil {
+		if err = c.reconnectSocket(socket); err == nil {
 			c.log.Debugf("reconnect OK")
 			return nil
 		}
@@ -354,6 +445,33 @@ func (c *Client) reconnect(socket *Socket, err error) error {
 	return err
 }
 
+// isTerminal returns true once client is being closed
+func (c *Client) isTerminal() bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.terminal
+}
+
+// sleep waits for d, returns false if client is shut down in the meantime
+func (c *Client) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.shutdown:
+		return false
+	}
+}
+
+// fail passes socket failure to Run, if it is not reported already
+func (c *Client) fail(err error) {
+	select {
+	case c.failed <- err:
+	default:
+	}
+}
+
 func (c *Client) dumpParams() {
 	c.log.Debug("----Bitfinex Client Parameters----")
 	c.log.Debugf("AutoReconnect=%t", c.parameters.AutoReconnect)
@@ -365,6 +483,13 @@ func (c *Client) dumpParams() {
 	c.log.Debugf("HeartbeatTimeout=%s", c.parameters.HeartbeatTimeout)
 	c.log.Debugf("URL=%s", c.parameters.URL)
 	c.log.Debugf("ManageOrderbook=%t", c.parameters.ManageOrderbook)
+	c.log.Debugf("OrderRateLimit=%d", c.parameters.OrderRateLimit)
+	c.log.Debugf("OrderRateInterval=%s", c.parameters.OrderRateInterval)
+	c.log.Debugf("SubscribeRateLimit=%d", c.parameters.SubscribeRateLimit)
+	c.log.Debugf("SubscribeRateInterval=%s", c.parameters.SubscribeRateInterval)
+	c.log.Debugf("SubscribeRetryAttempts=%d", c.parameters.SubscribeRetryAttempts)
+	c.log.Debugf("SubscribeRetryInterval=%s", c.parameters.SubscribeRetryInterval)
+	c.log.Debugf("SubscribeRetryTimeout=%s", c.parameters.SubscribeRetryTimeout)
 }
 
 func (c *Client) connectSocket(socketId SocketId) error {
@@ -393,7 +518,17 @@ func (c *Client) connectSocket(socketId SocketId) error {
 		// unable to establish connection
 		return err
 	}
+	// checked together with close setting terminal, so that close either
+	// closes the socket or waits for its listenUpstream
+	c.mtx.Lock()
+	if c.terminal {
+		c.mtx.Unlock()
+		socket.Asynchronous.Close()
+		return fmt.Errorf("client closed while connecting")
+	}
 	socket.IsConnected = true
+	c.waitGroup.Add(1)
+	c.mtx.Unlock()
 	go c.listenUpstream(socket)
 	return nil
 }
@@ -414,14 +549,18 @@ func (c *Client) reconnectSocket(socket *Socket) error {
 
 // start this goroutine before connecting, but this should die during a connection failure
 func (c *Client) listenUpstream(socket *Socket) {
+	defer c.waitGroup.Done()
 	for {
 		select {
 		case err := <-socket.Asynchronous.Done():
+			c.mtx.Lock()
+			socket.IsConnected = false
+			c.mtx.Unlock()
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway) {
 				err := c.reconnect(socket, err)
-				if err != nil {
+				if err != nil && !c.isTerminal() {
 					c.log.Errorf("Unable to reconnect socket (id=%d) after err: %s", socket.Id, err.Error())
-					return
+					c.fail(fmt.Errorf("socket (id=%d) lost: %s", socket.Id, err))
 				}
 			}
 			return
@@ -437,22 +576,19 @@ func (c *Client) listenUpstream(socket *Socket) {
 }
 
 func (c *Client) closeAsyncAndWait(socket *Socket, t time.Duration) {
-	timeout := make(chan bool)
+	timeout := time.NewTimer(t)
+	defer timeout.Stop()
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
 		select {
 		case <-socket.Asynchronous.Done():
 			wg.Done()
-		case <-timeout:
+		case <-timeout.C:
 			c.log.Errorf("socket (id=%d) took too long to close.", socket.Id)
 			wg.Done()
 		}
 	}()
-	go func() {
-		time.Sleep(t)
-		close(timeout)
-	}()
 	socket.Asynchronous.Close()
 	wg.Wait()
 }
@@ -501,7 +637,11 @@ func (c *Client) checkResubscription(socketId SocketId) {
 			defer cancel()
 			sub.Request.SubID = c.nonce.GetNonce() // new nonce
 			c.log.Infof("socket (id=%d) resubscribing to %s with nonce %s", socket.Id, sub.Request.String(), sub.Request.SubID)
-			_, err := c.subscribeBySocket(ctx, socket, sub.Request)
+			req := sub.Request
+			err := c.throttleSubscribe(ctx, func() error {
+				_, err := c.subscribeBySocket(ctx, socket, req)
+				return err
+			})
 			if err != nil {
 				c.log.Errorf("could not resubscribe: %s", err.Error())
 			}
@@ -559,9 +699,9 @@ func (c *Client) hasCredentials() bool {
 }
 
 // Authenticate creates the payload for the authentication request and sends it
-// to the API. The filters will be applied to the authenticated channel, i.e.
-// only subscribe to the filtered messages.
-func (c *Client) authenticate(ctx context.Context, socketId SocketId, filter ...string) error {
+// to the API. The filters set with AuthFilter will be applied to the authenticated
+// channel, i.e. only subscribe to the filtered messages.
+func (c *Client) authenticate(ctx context.Context, socketId SocketId) error {
 	nonce := c.nonce.GetNonce()
 	payload := "AUTH" + nonce
 	sig, err := c.sign(payload)
@@ -574,7 +714,7 @@ func (c *Client) authenticate(ctx context.Context, socketId SocketId, filter ...
 		AuthSig:     sig,
 		AuthPayload: payload,
 		AuthNonce:   nonce,
-		Filter:      filter,
+		Filter:      c.authFilter,
 		SubID:       nonce,
 	}
 	if c.cancelOnDisconnect {
@@ -621,6 +761,35 @@ func (c *Client) getMostAvailableSocket() (*Socket, error) {
 	return retSocket, nil
 }
 
+// returns connected socket with the most free capacity other than the given one,
+// starting a new connection if none of them has capacity left
+func (c *Client) getAvailableSocketExcept(socketId SocketId) (*Socket, error) {
+	var retSocket *Socket
+	bestCapacity := 0
+	c.mtx.RLock()
+	socks := make([]*Socket, 0, len(c.sockets))
+	for _, socket := range c.sockets {
+		if socket.Id != socketId && socket.IsConnected {
+			socks = append(socks, socket)
+		}
+	}
+	c.mtx.RUnlock()
+	for _, socket := range socks {
+		if capac := c.getAvailableSocketCapacity(socket.Id); capac > bestCapacity {
+			retSocket = socket
+			bestCapacity = capac
+		}
+	}
+	if retSocket != nil {
+		return retSocket, nil
+	}
+	socketId = SocketId(c.ConnectionCount())
+	if err := c.connectSocket(socketId); err != nil {
+		return nil, err
+	}
+	return c.socketById(socketId)
+}
+
 // lookup the socket with the given Id, throw error if not found
 func (c *Client) socketById(socketId SocketId) (*Socket, error) {
 	c.mtx.RLock()
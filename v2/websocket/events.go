@@ -59,6 +59,7 @@ const (
 	ErrorCodeSubscriptionFailed   int = 10300
 	ErrorCodeAlreadySubscribed    int = 10301
 	ErrorCodeUnknownChannel       int = 10302
+	ErrorCodeChannelLimit         int = 10305
 	ErrorCodeUnsubscribeFailed    int = 10400
 	ErrorCodeNotSubscribed        int = 10401
 )
@@ -121,7 +122,7 @@ func (c *Client) handleEvent(socketId SocketId, msg []byte) error {
 				return err_open
 			}
 		}
-		c.listener <- &i
+		c.publish(&i)
 	case "auth":
 		a := AuthEvent{}
 		err = json.Unmarshal(msg, &a)
@@ -134,7 +135,7 @@ func (c *Client) handleEvent(socketId SocketId, msg []byte) error {
 			c.Authentication = RejectedAuthentication
 		}
 		c.handleAuthAck(socketId, &a)
-		c.listener <- &a
+		c.publish(&a)
 		return nil
 	case "subscribed":
 		s := SubscribeEvent{}
@@ -146,7 +147,10 @@ func (c *Client) handleEvent(socketId SocketId, msg []byte) error {
 		if err != nil {
 			return err
 		}
-		c.listener <- &s
+		c.mtx.Lock()
+		delete(c.subscribeRetries, s.SubID)
+		c.mtx.Unlock()
+		c.publish(&s)
 		return nil
 	case "unsubscribed":
 		s := UnsubscribeEvent{}
@@ -158,21 +162,26 @@ func (c *Client) handleEvent(socketId SocketId, msg []byte) error {
 		if err_rem != nil {
 			return err_rem
 		}
-		c.listener <- &s
+		c.publish(&s)
 	case "error":
 		er := ErrorEvent{}
 		err = json.Unmarshal(msg, &er)
 		if err != nil {
 			return err
 		}
-		c.listener <- &er
+		c.publish(&er)
+		if er.Code == ErrorCodeChannelLimit && er.SubID != "" {
+			if err_retry := c.retrySubscription(socketId, er.SubID); err_retry != nil {
+				c.log.Errorf("subscription retry failed: %s", err_retry.Error())
+			}
+		}
 	case "conf":
 		ec := ConfEvent{}
 		err = json.Unmarshal(msg, &ec)
 		if err != nil {
 			return err
 		}
-		c.listener <- &ec
+		c.publish(&ec)
 	default:
 		c.log.Warningf("unknown event: %s", msg)
 	}
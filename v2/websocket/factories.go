@@ -18,6 +18,13 @@ type messageFactory interface {
 	BuildSnapshot(sub *subscription, raw [][]interface{}, raw_bytes []byte) (interface{}, error)
 }
 
+// frameFactory is implemented by factories of high volume channels. Messages
+// are built straight from raw json payload of a frame, skipping generic decoding
+type frameFactory interface {
+	BuildFrame(sub *subscription, objType string, pld []byte) (interface{}, error)
+	BuildFrameSnapshot(sub *subscription, pld []byte) (interface{}, error)
+}
+
 type TickerFactory struct {
 	*subscriptions
 }
@@ -36,6 +43,14 @@ func (f *TickerFactory) BuildSnapshot(sub *subscription, raw [][]interface{}, ra
 	return ticker.SnapshotFromRaw(sub.Request.Symbol, raw)
 }
 
+func (f *TickerFactory) BuildFrame(sub *subscription, objType string, pld []byte) (interface{}, error) {
+	return ticker.FromJSON(sub.Request.Symbol, pld)
+}
+
+func (f *TickerFactory) BuildFrameSnapshot(sub *subscription, pld []byte) (interface{}, error) {
+	return ticker.SnapshotFromJSON(sub.Request.Symbol, pld)
+}
+
 type TradeFactory struct {
 	*subscriptions
 }
@@ -57,6 +72,17 @@ func (f *TradeFactory) BuildSnapshot(sub *subscription, raw [][]interface{}, raw
 	return trade.SnapshotFromRaw(sub.Request.Symbol, raw)
 }
 
+func (f *TradeFactory) BuildFrame(sub *subscription, objType string, pld []byte) (interface{}, error) {
+	if "tu" == objType {
+		return nil, nil // do not process TradeUpdate messages on public feed, only need to process TradeExecution (first copy seen)
+	}
+	return trade.FromJSON(sub.Request.Symbol, pld)
+}
+
+func (f *TradeFactory) BuildFrameSnapshot(sub *subscription, pld []byte) (interface{}, error) {
+	return trade.SnapshotFromJSON(sub.Request.Symbol, pld)
+}
+
 type BookFactory struct {
 	*subscriptions
 	orderbooks  map[string]*Orderbook
@@ -92,15 +118,12 @@ func (f *BookFactory) Build(sub *subscription, objType string, raw []interface{}
 	}
 
 	update, err := book.FromRaw(sub.Request.Symbol, sub.Request.Precision, raw, rawJSONNumbers[1])
-	if f.manageBooks {
-		f.lock.Lock()
-		defer f.lock.Unlock()
-		if orderbook, ok := f.orderbooks[sub.Request.Symbol]; ok {
-			orderbook.UpdateWith(update)
-		}
+	if err != nil {
+		return nil, err
 	}
 
-	return update, err
+	f.update(sub, update)
+	return update, nil
 }
 
 func (f *BookFactory) BuildSnapshot(sub *subscription, raw [][]interface{}, b []byte) (interface{}, error) {
@@ -114,21 +137,60 @@ func (f *BookFactory) BuildSnapshot(sub *subscription, raw [][]interface{}, b []
 		return nil, err
 	}
 
-	if f.manageBooks {
-		f.lock.Lock()
-		defer f.lock.Unlock()
-		// create new orderbook
-		f.orderbooks[sub.Request.Symbol] = &Orderbook{
-			symbol: sub.Request.Symbol,
-			bids:   make([]*book.Book, 0),
-			asks:   make([]*book.Book, 0),
-		}
-		f.orderbooks[sub.Request.Symbol].SetWithSnapshot(update)
+	f.reset(sub, update)
+	return update, nil
+}
+
+func (f *BookFactory) BuildFrame(sub *subscription, objType string, pld []byte) (interface{}, error) {
+	update, err := book.FromJSON(sub.Request.Symbol, sub.Request.Precision, pld)
+	if err != nil {
+		return nil, err
+	}
+
+	f.update(sub, update)
+	return update, nil
+}
+
+func (f *BookFactory) BuildFrameSnapshot(sub *subscription, pld []byte) (interface{}, error) {
+	update, err := book.SnapshotFromJSON(sub.Request.Symbol, sub.Request.Precision, pld)
+	if err != nil {
+		return nil, err
 	}
 
+	f.reset(sub, update)
 	return update, nil
 }
 
+// update applies book update to managed orderbook
+func (f *BookFactory) update(sub *subscription, update *book.Book) {
+	if !f.manageBooks {
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if orderbook, ok := f.orderbooks[sub.Request.Symbol]; ok {
+		orderbook.UpdateWith(update)
+	}
+}
+
+// reset replaces managed orderbook with snapshot
+func (f *BookFactory) reset(sub *subscription, snapshot *book.Snapshot) {
+	if !f.manageBooks {
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	// create new orderbook
+	f.orderbooks[sub.Request.Symbol] = &Orderbook{
+		symbol: sub.Request.Symbol,
+		bids:   make([]*book.Book, 0),
+		asks:   make([]*book.Book, 0),
+	}
+	f.orderbooks[sub.Request.Symbol].SetWithSnapshot(snapshot)
+}
+
 type CandlesFactory struct {
 	*subscriptions
 }
@@ -0,0 +1,90 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+)
+
+// frame is a channel message split into its parts, payload is left undecoded.
+// Messages come as [CHAN_ID, PAYLOAD], [CHAN_ID, "TYPE"] or [CHAN_ID, "TYPE", PAYLOAD]
+type frame struct {
+	// number of message elements
+	len    int
+	chanID int64
+	typ    string
+	pld    []byte
+}
+
+// parseFrame tokenizes channel message once, without building intermediate values
+func parseFrame(msg []byte) (f frame, err error) {
+	var buf [4][]byte
+	parts, err := convert.SplitArray(buf[:0], msg)
+	if err != nil {
+		return f, err
+	}
+
+	f.len = len(parts)
+	if f.len < 2 {
+		return f, nil
+	}
+
+	if !convert.IsNumber(parts[0]) {
+		return f, fmt.Errorf("expected message to start with a channel id but got %s instead", parts[0])
+	}
+	f.chanID = convert.I64BytesOrZero(parts[0])
+
+	if parts[1][0] != '"' {
+		f.pld = parts[1]
+		return f, nil
+	}
+
+	if f.typ, err = frameType(parts[1]); err != nil {
+		return f, err
+	}
+	if f.len > 2 {
+		f.pld = parts[2]
+	}
+	return f, nil
+}
+
+// frameType unquotes message type, common ones are returned without allocating
+func frameType(b []byte) (string, error) {
+	switch string(b) {
+	case `"hb"`:
+		return "hb", nil
+	case `"cs"`:
+		return "cs", nil
+	case `"te"`:
+		return "te", nil
+	case `"tu"`:
+		return "tu", nil
+	case `"fte"`:
+		return "fte", nil
+	case `"ftu"`:
+		return "ftu", nil
+	}
+
+	var typ string
+	err := json.Unmarshal(b, &typ)
+	return typ, err
+}
+
+// payloadShape reports whether raw payload is an empty array or a snapshot,
+// which is an array of arrays. Only the beginning of payload is looked at
+func payloadShape(pld []byte) (empty, snapshot bool) {
+	t := bytes.TrimLeft(pld, jsonSpace)
+	if len(t) == 0 || t[0] != '[' {
+		return true, false
+	}
+
+	t = bytes.TrimLeft(t[1:], jsonSpace)
+	if len(t) == 0 || t[0] == ']' {
+		return true, false
+	}
+	return false, t[0] == '['
+}
+
+const jsonSpace = " \t\r\n"
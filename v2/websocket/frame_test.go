@@ -0,0 +1,196 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitfinexcom/bitfinex-api-go/pkg/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrame(t *testing.T) {
+	cases := map[string]struct {
+		msg      string
+		expected frame
+		err      bool
+	}{
+		"heartbeat": {
+			msg:      `[17082,"hb"]`,
+			expected: frame{len: 2, chanID: 17082, typ: "hb"},
+		},
+		"checksum": {
+			msg:      `[17082,"cs",-1324310232]`,
+			expected: frame{len: 3, chanID: 17082, typ: "cs", pld: []byte(`-1324310232`)},
+		},
+		"update": {
+			msg:      `[17082, [7254.7,3,3.3]]`,
+			expected: frame{len: 2, chanID: 17082, pld: []byte(`[7254.7,3,3.3]`)},
+		},
+		"typed update": {
+			msg:      `[17082,"te",[401597395,1574694478808,0.005,7245.3]]`,
+			expected: frame{len: 3, chanID: 17082, typ: "te", pld: []byte(`[401597395,1574694478808,0.005,7245.3]`)},
+		},
+		"uncommon type": {
+			msg:      `[0,"os",[]]`,
+			expected: frame{len: 3, typ: "os", pld: []byte(`[]`)},
+		},
+		"empty": {
+			msg:      `[]`,
+			expected: frame{},
+		},
+		"invalid channel id": {
+			msg: `["17082",[7254.7,3,3.3]]`,
+			err: true,
+		},
+		"not an array": {
+			msg: `{"event":"info"}`,
+			err: true,
+		},
+		"truncated": {
+			msg: `[17082,[7254.7,3,3.3]`,
+			err: true,
+		},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := parseFrame([]byte(v.msg))
+			if v.err {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, v.expected, got)
+		})
+	}
+}
+
+func TestPayloadShape(t *testing.T) {
+	cases := map[string]struct {
+		pld      string
+		empty    bool
+		snapshot bool
+	}{
+		"update":         {pld: `[7254.7,3,3.3]`},
+		"snapshot":       {pld: ` [ [7254.7,3,3.3]]`, snapshot: true},
+		"empty snapshot": {pld: `[ ]`, empty: true},
+		"not an array":   {pld: `7254.7`, empty: true},
+		"missing":        {empty: true},
+	}
+
+	for k, v := range cases {
+		t.Run(k, func(t *testing.T) {
+			empty, snapshot := payloadShape([]byte(v.pld))
+			assert.Equal(t, v.empty, empty)
+			assert.Equal(t, v.snapshot, snapshot)
+		})
+	}
+}
+
+// frameCases are public channel messages decoded by legacy and frame paths
+var frameCases = map[string]struct {
+	factory func(subs *subscriptions) messageFactory
+	request SubscriptionRequest
+	msg     string
+}{
+	"book": {
+		factory: func(subs *subscriptions) messageFactory { return newBookFactory(subs, map[string]*Orderbook{}, false) },
+		request: SubscriptionRequest{Channel: ChanBook, Symbol: "tBTCUSD", Precision: "P0"},
+		msg:     `[17082,[7254.7,3,3.3]]`,
+	},
+	"book snapshot": {
+		factory: func(subs *subscriptions) messageFactory { return newBookFactory(subs, map[string]*Orderbook{}, false) },
+		request: SubscriptionRequest{Channel: ChanBook, Symbol: "tBTCUSD", Precision: "P0"},
+		msg:     `[17082,` + bookSnapshot(50) + `]`,
+	},
+	"trades": {
+		factory: func(subs *subscriptions) messageFactory { return newTradeFactory(subs) },
+		request: SubscriptionRequest{Channel: ChanTrades, Symbol: "tBTCUSD"},
+		msg:     `[17082,"te",[401597395,1574694478808,0.005,7245.3]]`,
+	},
+	"ticker": {
+		factory: func(subs *subscriptions) messageFactory { return newTickerFactory(subs) },
+		request: SubscriptionRequest{Channel: ChanTicker, Symbol: "tBTCUSD"},
+		msg:     `[17082,[7616.5,31.89055171,7617.5,43.358118629999986,-550.8,-0.0674,7617.1,8314.71200815,8257.8,7500]]`,
+	},
+}
+
+func bookSnapshot(levels int) string {
+	raw := make([][]float64, 0, levels*2)
+	for i := 0; i < levels; i++ {
+		raw = append(raw, []float64{7254.7 - float64(i)/10, 3, 3.3})
+		raw = append(raw, []float64{7254.8 + float64(i)/10, 2, -1.5})
+	}
+	b, _ := json.Marshal(raw)
+	return string(b)
+}
+
+// buildLegacy decodes message generically, the way it was done before frames
+func buildLegacy(factory messageFactory, sub *subscription, msg []byte) (interface{}, error) {
+	var raw []interface{}
+	if err := json.Unmarshal(msg, &raw); err != nil {
+		return nil, err
+	}
+
+	objType, _ := raw[1].(string)
+	data, _ := raw[len(raw)-1].([]interface{})
+	if len(data) > 0 {
+		if _, ok := data[0].([]interface{}); ok {
+			return factory.BuildSnapshot(sub, convert.ToInterfaceArray(data), msg)
+		}
+	}
+	return factory.Build(sub, objType, data, msg)
+}
+
+// buildFrame decodes message in a single pass
+func buildFrame(factory messageFactory, sub *subscription, msg []byte) (interface{}, error) {
+	f, err := parseFrame(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ff := factory.(frameFactory)
+	if _, snapshot := payloadShape(f.pld); snapshot {
+		return ff.BuildFrameSnapshot(sub, f.pld)
+	}
+	return ff.BuildFrame(sub, f.typ, f.pld)
+}
+
+func TestBuildFrame(t *testing.T) {
+	for k, v := range frameCases {
+		t.Run(k, func(t *testing.T) {
+			sub := &subscription{ChanID: 17082, Public: true, Request: &v.request}
+			factory := v.factory(newSubscriptions(0, nil))
+
+			expected, err := buildLegacy(factory, sub, []byte(v.msg))
+			require.Nil(t, err)
+
+			got, err := buildFrame(factory, sub, []byte(v.msg))
+			require.Nil(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func BenchmarkBuildFrame(b *testing.B) {
+	for k, v := range frameCases {
+		sub := &subscription{ChanID: 17082, Public: true, Request: &v.request}
+		factory := v.factory(newSubscriptions(0, nil))
+		msg := []byte(v.msg)
+
+		for name, build := range map[string]func(messageFactory, *subscription, []byte) (interface{}, error){
+			"legacy": buildLegacy,
+			"frame":  buildFrame,
+		} {
+			b.Run(k+"/"+name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := build(factory, sub, msg); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
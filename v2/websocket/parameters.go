@@ -22,6 +22,23 @@ type Parameters struct {
 
 	URL                    string
 	ManageOrderbook        bool
+
+	// OrderRateLimit caps authenticated order operations sent per
+	// OrderRateInterval. Excess operations are queued with cancels first
+	// and successive updates of the same order coalesced. 0 disables it
+	OrderRateLimit         int
+	OrderRateInterval      time.Duration
+
+	// SubscribeRateLimit caps subscribe requests sent per SubscribeRateInterval,
+	// including resubscriptions after reconnect. 0 disables it
+	SubscribeRateLimit     int
+	SubscribeRateInterval  time.Duration
+	// subscriptions rejected due to socket channel limit (10305) are retried on
+	// another socket up to SubscribeRetryAttempts times, every retry waits
+	// SubscribeRetryInterval and gives up after SubscribeRetryTimeout
+	SubscribeRetryAttempts int
+	SubscribeRetryInterval time.Duration
+	SubscribeRetryTimeout  time.Duration
 }
 
 func NewDefaultParameters() *Parameters {
@@ -37,6 +54,13 @@ func NewDefaultParameters() *Parameters {
 		ResubscribeOnReconnect: true,
 		HeartbeatTimeout:       time.Second * 30,
 		LogTransport:           false,           // log transport send/recv
+		OrderRateLimit:         0,
+		OrderRateInterval:      time.Second,
+		SubscribeRateLimit:     0,
+		SubscribeRateInterval:  time.Second,
+		SubscribeRetryAttempts: 3,
+		SubscribeRetryInterval: time.Second,
+		SubscribeRetryTimeout:  time.Second * 5,
 		Logger:                 logging.MustGetLogger("bitfinex-ws"),
 	}
 }
@@ -0,0 +1,70 @@
+package websocket
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubscriptionPlan describes how a set of subscriptions would be spread
+// across websocket connections
+type SubscriptionPlan struct {
+	// number of unique subscriptions in the set
+	Subscriptions int
+	// number of connections needed to hold the subscriptions
+	Sockets int
+	// number of channels, including auth channel, taken on each connection
+	PerSocket []int
+	// time needed to send all subscribe requests under SubscribeRateLimit
+	EstimatedDuration time.Duration
+}
+
+// PlanSubscriptions reports how many sockets the given set of subscriptions will
+// need, spreading them the same way Subscribe does. It takes CapacityPerConnection,
+// SubscribeRateLimit and client credentials into account, without connecting.
+// Duplicated requests are counted once.
+func (c *Client) PlanSubscriptions(reqs []*SubscriptionRequest) SubscriptionPlan {
+	unique := make(map[string]bool)
+	for _, req := range reqs {
+		unique[planKey(req)] = true
+	}
+
+	capacity := c.parameters.CapacityPerConnection
+	// Connect always opens the first socket
+	used := []int{0}
+	if c.hasCredentials() {
+		used[0]++
+	}
+
+	for i := 0; i < len(unique); i++ {
+		available := 0
+		for _, u := range used {
+			available += capacity - u
+		}
+		if available <= 1 {
+			used = append(used, 0)
+		}
+		// socket with highest available capacity, lowest id on ties
+		best := 0
+		for id, u := range used {
+			if u < used[best] {
+				best = id
+			}
+		}
+		used[best]++
+	}
+
+	plan := SubscriptionPlan{
+		Subscriptions: len(unique),
+		Sockets:       len(used),
+		PerSocket:     used,
+	}
+	if limit := c.parameters.SubscribeRateLimit; limit > 0 && len(unique) > 0 {
+		batches := (len(unique) + limit - 1) / limit
+		plan.EstimatedDuration = time.Duration(batches-1) * c.parameters.SubscribeRateInterval
+	}
+	return plan
+}
+
+func planKey(req *SubscriptionRequest) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s", req.Channel, req.Symbol, req.Precision, req.Frequency, req.Len, req.Key)
+}
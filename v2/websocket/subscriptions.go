@@ -205,19 +205,28 @@ func (s *subscriptions) sweep(exp time.Time) {
 	}
 	s.lock.RUnlock()
 	for _, dis := range disconnects {
-		s.hbDisconnect <- dis
+		select {
+		case s.hbDisconnect <- dis:
+		case <-s.hbShutdown:
+			return
+		}
 	}
 }
 
 func (s *subscriptions) control() {
+	sleep := s.hbSleep
+	if sleep <= 0 {
+		sleep = time.Second
+	}
+	ticker := time.NewTicker(sleep)
+	defer ticker.Stop()
 	for {
+		s.sweep(time.Now())
 		select {
 		case <-s.hbShutdown:
 			return
-		default:
+		case <-ticker.C:
 		}
-		s.sweep(time.Now())
-		time.Sleep(s.hbSleep)
 	}
 }
 